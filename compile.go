@@ -0,0 +1,159 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// compiledMessage is a localization precompiled
+// into a list of literal and verb tokens, so that
+// the format is not parsed on every translation.
+type compiledMessage struct {
+	format string
+	tokens []token
+	// verbs is the number of verb tokens.
+	verbs int
+	// simple is false if the format uses verbs flags, width,
+	// precision or argument indexes, fmt.Sprintf is used then.
+	simple bool
+}
+
+// token is a literal, when verb is 0, or a verb.
+type token struct {
+	literal string
+	verb    byte
+}
+
+// compile parse the fmt format of a localization.
+func compile(format string) *compiledMessage {
+	m := &compiledMessage{format: format, simple: true}
+
+	var literal strings.Builder
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c != '%' {
+			literal.WriteByte(c)
+			continue
+		}
+
+		if i+1 >= len(format) {
+			m.simple = false
+			break
+		}
+		i++
+
+		switch verb := format[i]; verb {
+		case '%':
+			literal.WriteByte('%')
+		case 's', 'd', 'v':
+			if literal.Len() > 0 {
+				m.tokens = append(m.tokens, token{literal: literal.String()})
+				literal.Reset()
+			}
+			m.tokens = append(m.tokens, token{verb: verb})
+			m.verbs++
+		default:
+			m.simple = false
+		}
+	}
+
+	if literal.Len() > 0 {
+		m.tokens = append(m.tokens, token{literal: literal.String()})
+	}
+	return m
+}
+
+// render format the message with params,
+// as fmt.Sprintf(format, params...) would do.
+func (m *compiledMessage) render(params []interface{}) string {
+	if !m.simple || m.verbs != len(params) {
+		return fmt.Sprintf(m.format, params...)
+	}
+
+	if m.verbs == 0 {
+		if len(m.tokens) == 0 {
+			return ""
+		}
+		return m.tokens[0].literal
+	}
+
+	var b strings.Builder
+	b.Grow(len(m.format) + 8*m.verbs)
+	p := 0
+	for _, t := range m.tokens {
+		if t.verb == 0 {
+			b.WriteString(t.literal)
+			continue
+		}
+
+		param := params[p]
+		p++
+		switch v := param.(type) {
+		case string:
+			if t.verb != 'd' {
+				b.WriteString(v)
+				continue
+			}
+		case int:
+			if t.verb != 's' {
+				b.WriteString(strconv.Itoa(v))
+				continue
+			}
+		}
+		fmt.Fprintf(&b, "%"+string(t.verb), param)
+	}
+	return b.String()
+}
+
+// messageKey identify a compiled message,
+// namespace is empty for the main localizations.
+type messageKey struct {
+	namespace, locale, key string
+}
+
+// messageCache caches the compiled messages.
+type messageCache struct {
+	mutex    sync.RWMutex
+	messages map[messageKey]*compiledMessage
+}
+
+func (c *messageCache) get(k messageKey) (*compiledMessage, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	m, ok := c.messages[k]
+	return m, ok
+}
+
+func (c *messageCache) put(k messageKey, m *compiledMessage) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.messages == nil {
+		c.messages = make(map[messageKey]*compiledMessage)
+	}
+	c.messages[k] = m
+}
+
+// reset drop the compiled messages of namespace.
+func (c *messageCache) reset(namespace string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for k := range c.messages {
+		if k.namespace == namespace {
+			delete(c.messages, k)
+		}
+	}
+}
+
+// precompile compile every localization of store in i18n.Tags.
+func (i18n *I18n) precompile(namespace string, store Store) {
+	i18n.messages.reset(namespace)
+	for _, locale := range i18n.locales {
+		for _, key := range store.Keys(locale) {
+			if localization, ok := store.Lookup(locale, key); ok {
+				i18n.messages.put(messageKey{namespace, locale, key}, compile(localization))
+			}
+		}
+	}
+}
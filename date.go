@@ -0,0 +1,357 @@
+package i18n
+
+//go:generate go run gen.go
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// DateStyle is the CLDR length of date and time patterns.
+type DateStyle int
+
+const (
+	DateStyleShort  DateStyle = iota // e.g.: `1/2/06`, `3:04 PM`
+	DateStyleMedium                  // e.g.: `Jan 2, 2006`, `3:04:05 PM`
+	DateStyleLong                    // e.g.: `January 2, 2006`, `3:04:05 PM MST`
+	DateStyleFull                    // e.g.: `Monday, January 2, 2006`
+)
+
+// index return the patterns index of style,
+// unknown styles fall back to DateStyleMedium.
+func (style DateStyle) index() DateStyle {
+	if style < DateStyleShort || style > DateStyleFull {
+		return DateStyleMedium
+	}
+	return style
+}
+
+// relativeUnit index cldrLocale.relative.
+type relativeUnit int
+
+const (
+	relativeSecond relativeUnit = iota
+	relativeMinute
+	relativeHour
+	relativeDay
+	relativeWeek
+	relativeMonth
+	relativeYear
+	numRelativeUnits
+)
+
+// cldrLocale holds the CLDR gregorian calendar
+// and relative time data of a locale, see tables.go.
+type cldrLocale struct {
+	monthsAbbreviated           [12]string
+	monthsWide                  [12]string
+	standAloneMonthsAbbreviated [12]string
+	standAloneMonthsWide        [12]string
+	daysAbbreviated             [7]string
+	daysWide                    [7]string
+	standAloneDaysAbbreviated   [7]string
+	standAloneDaysWide          [7]string
+	dayPeriods                  [2]string
+	eras                        [2]string
+	// dateFormats, timeFormats and dateTimeFormats are indexed by DateStyle.
+	dateFormats     [4]string
+	timeFormats     [4]string
+	dateTimeFormats [4]string
+	relative        [numRelativeUnits]cldrRelative
+}
+
+// cldrRelative holds the relative time patterns of a unit.
+type cldrRelative struct {
+	// previous, current and next are e.g.: `yesterday`, `today` and `tomorrow`.
+	previous, current, next string
+	// future and past are indexed by plural.Form.
+	future, past [6]string
+}
+
+// cldrLocaleForTag return the CLDR data for tag,
+// following the CLDR parent chain (e.g.: en-AU, en-001, en),
+// then the base language, English is the last resort.
+func cldrLocaleForTag(tag language.Tag) *cldrLocale {
+	for t := tag; t != language.Und; t = t.Parent() {
+		if data, ok := cldrLocales[t.String()]; ok {
+			return data
+		}
+	}
+	if base, _ := tag.Base(); base.String() != "und" {
+		if data, ok := cldrLocales[base.String()]; ok {
+			return data
+		}
+	}
+	return cldrLocales["en"]
+}
+
+// FormatDate format the date of t using the
+// CLDR date pattern of the given style and locale,
+// e.g.: `Jan 2, 2006` (en) or `2 gen 2006` (it) for DateStyleMedium.
+// Unknown styles fall back to DateStyleMedium.
+// All the CLDR locales in golang.org/x/text are covered,
+// regional variants follow the CLDR parent chain
+// (e.g.: en-AU uses en-001, sr-Latn is not Cyrillic),
+// unknown languages fall back to English.
+func (i18n *I18n) FormatDate(locale string, t time.Time, style DateStyle) string {
+	return cldrLocaleForTag(i18n.MatchAvailableLanguageTag(locale)).formatDate(t, style)
+}
+
+// FormatTime format the time of t using the
+// CLDR time pattern of the given style and locale,
+// e.g.: `3:04 PM` (en) or `15:04` (it) for DateStyleShort.
+// Unknown styles fall back to DateStyleMedium,
+// the locale coverage is the one of FormatDate.
+func (i18n *I18n) FormatTime(locale string, t time.Time, style DateStyle) string {
+	return cldrLocaleForTag(i18n.MatchAvailableLanguageTag(locale)).formatTime(t, style)
+}
+
+// FormatDateTime format t combining FormatDate and FormatTime
+// using the CLDR date-time pattern of the given style and locale.
+// Unknown styles fall back to DateStyleMedium,
+// the locale coverage is the one of FormatDate.
+func (i18n *I18n) FormatDateTime(locale string, t time.Time, style DateStyle) string {
+	return cldrLocaleForTag(i18n.MatchAvailableLanguageTag(locale)).formatDateTime(t, style)
+}
+
+// FormatRelativeTime format d as a time relative to now,
+// negative values are in the past,
+// e.g.: `3 days ago`, `in 2 hours` or `yesterday` (en),
+// `3 giorni fa`, `tra 2 ore` or `ieri` (it).
+// The largest unit in which d, rounded to the next smaller unit,
+// is at least one is used: seconds, minutes, hours, days,
+// weeks (7 days), months (30 days) and years (365 days),
+// e.g.: 23h59m is `tomorrow` and 6.5 days is `next week`.
+func (i18n *I18n) FormatRelativeTime(locale string, d time.Duration) string {
+	tag := i18n.MatchAvailableLanguageTag(locale)
+	return formatRelativeTime(tag, d)
+}
+
+func (data *cldrLocale) formatDate(t time.Time, style DateStyle) string {
+	return data.format(data.dateFormats[style.index()], t)
+}
+
+func (data *cldrLocale) formatTime(t time.Time, style DateStyle) string {
+	return data.format(data.timeFormats[style.index()], t)
+}
+
+func (data *cldrLocale) formatDateTime(t time.Time, style DateStyle) string {
+	style = style.index()
+	date := data.formatDate(t, style)
+	clock := data.formatTime(t, style)
+	return strings.NewReplacer("{1}", date, "{0}", clock).Replace(unquote(data.dateTimeFormats[style]))
+}
+
+var relativeUnits = []struct {
+	unit     relativeUnit
+	duration time.Duration
+}{
+	{relativeYear, 365 * 24 * time.Hour},
+	{relativeMonth, 30 * 24 * time.Hour},
+	{relativeWeek, 7 * 24 * time.Hour},
+	{relativeDay, 24 * time.Hour},
+	{relativeHour, time.Hour},
+	{relativeMinute, time.Minute},
+	{relativeSecond, time.Second},
+}
+
+func formatRelativeTime(tag language.Tag, d time.Duration) string {
+	data := cldrLocaleForTag(tag)
+
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+
+	// round to the next smaller unit before picking one,
+	// so that 23h59m is `tomorrow` and not `in 24 hours`.
+	unit, n := relativeSecond, int64(abs.Round(time.Second)/time.Second)
+	for i, u := range relativeUnits[:len(relativeUnits)-1] {
+		if abs.Round(relativeUnits[i+1].duration) >= u.duration {
+			unit, n = u.unit, int64(abs.Round(u.duration)/u.duration)
+			break
+		}
+	}
+
+	rel := data.relative[unit]
+	switch {
+	case n == 0 && len(rel.current) > 0:
+		return rel.current
+	case n == 1 && d < 0 && len(rel.previous) > 0:
+		return rel.previous
+	case n == 1 && d > 0 && len(rel.next) > 0:
+		return rel.next
+	}
+
+	forms := rel.future
+	if d < 0 {
+		forms = rel.past
+	}
+
+	form := plural.Cardinal.MatchPlural(tag, int(n), 0, 0, 0, 0)
+	pattern := forms[form]
+	if len(pattern) == 0 {
+		pattern = forms[plural.Other]
+	}
+
+	return strings.Replace(pattern, "{0}", formatNumber(tag, n), 1)
+}
+
+// format the time t using the CLDR date format pattern.
+// Supported fields are: G, y, M, L, d, E, c, a, h, H, K, k, m, s, S,
+// z, v, Z, O, x and X. Time zone names are the Go abbreviations.
+func (data *cldrLocale) format(pattern string, t time.Time) string {
+	var b strings.Builder
+
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+
+		// quoted literal, '' is a single quote
+		if c == '\'' {
+			j := i + 1
+			for ; j < len(pattern); j++ {
+				if pattern[j] == '\'' {
+					if j+1 < len(pattern) && pattern[j+1] == '\'' {
+						b.WriteByte('\'')
+						j++
+						continue
+					}
+					break
+				}
+				b.WriteByte(pattern[j])
+			}
+			if j == i+1 && j < len(pattern) {
+				b.WriteByte('\'')
+			}
+			i = j + 1
+			continue
+		}
+
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+
+		count := 1
+		for i+count < len(pattern) && pattern[i+count] == c {
+			count++
+		}
+		i += count
+
+		b.WriteString(data.formatField(c, count, t))
+	}
+
+	return b.String()
+}
+
+func (data *cldrLocale) formatField(field byte, count int, t time.Time) string {
+	switch field {
+	case 'G':
+		if t.Year() <= 0 {
+			return data.eras[0]
+		}
+		return data.eras[1]
+	case 'y':
+		if count == 2 {
+			return pad(t.Year()%100, 2)
+		}
+		return pad(t.Year(), count)
+	case 'M', 'L':
+		standAlone := field == 'L'
+		switch {
+		case count >= 4 && standAlone:
+			return data.standAloneMonthsWide[t.Month()-1]
+		case count >= 4:
+			return data.monthsWide[t.Month()-1]
+		case count == 3 && standAlone:
+			return data.standAloneMonthsAbbreviated[t.Month()-1]
+		case count == 3:
+			return data.monthsAbbreviated[t.Month()-1]
+		}
+		return pad(int(t.Month()), count)
+	case 'd':
+		return pad(t.Day(), count)
+	case 'E', 'c':
+		standAlone := field == 'c'
+		switch {
+		case field == 'c' && count <= 2:
+			return strconv.Itoa(int(t.Weekday()) + 1)
+		case count >= 4 && standAlone:
+			return data.standAloneDaysWide[t.Weekday()]
+		case count >= 4:
+			return data.daysWide[t.Weekday()]
+		case standAlone:
+			return data.standAloneDaysAbbreviated[t.Weekday()]
+		}
+		return data.daysAbbreviated[t.Weekday()]
+	case 'a', 'b', 'B':
+		if t.Hour() < 12 {
+			return data.dayPeriods[0]
+		}
+		return data.dayPeriods[1]
+	case 'h':
+		h := t.Hour() % 12
+		if h == 0 {
+			h = 12
+		}
+		return pad(h, count)
+	case 'H':
+		return pad(t.Hour(), count)
+	case 'K':
+		return pad(t.Hour()%12, count)
+	case 'k':
+		h := t.Hour()
+		if h == 0 {
+			h = 24
+		}
+		return pad(h, count)
+	case 'm':
+		return pad(t.Minute(), count)
+	case 's':
+		return pad(t.Second(), count)
+	case 'S':
+		fraction := fmt.Sprintf("%09d", t.Nanosecond())
+		if count < len(fraction) {
+			return fraction[:count]
+		}
+		return fraction
+	case 'z', 'v', 'V':
+		name, _ := t.Zone()
+		return name
+	case 'Z':
+		if count == 4 {
+			return "GMT" + t.Format("-07:00")
+		}
+		return t.Format("-0700")
+	case 'O':
+		return "GMT" + t.Format("-07:00")
+	case 'x':
+		return t.Format("-0700")
+	case 'X':
+		return t.Format("Z0700")
+	}
+	return ""
+}
+
+// pad format n with at least the given number of digits.
+func pad(n int, digits int) string {
+	s := strconv.Itoa(n)
+	if len(s) >= digits {
+		return s
+	}
+	return strings.Repeat("0", digits-len(s)) + s
+}
+
+// unquote remove the quotes from the literals of a CLDR pattern.
+func unquote(pattern string) string {
+	if !strings.Contains(pattern, "'") {
+		return pattern
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(strings.ReplaceAll(pattern, "''", "\x00"), "'", ""), "\x00", "'")
+}
@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// Direction is the text direction of a locale,
+// usable as the HTML `dir` attribute value.
+type Direction string
+
+const (
+	DirectionLTR Direction = "ltr"
+	DirectionRTL Direction = "rtl"
+)
+
+// rtlScripts are the right-to-left scripts ISO 15924 codes.
+var rtlScripts = map[string]bool{
+	"Adlm": true, "Arab": true, "Aran": true, "Hebr": true, "Mand": true, "Mend": true,
+	"Nkoo": true, "Rohg": true, "Samr": true, "Syrc": true, "Thaa": true, "Yezi": true,
+}
+
+// LocaleInfo describe a locale, useful to build language pickers.
+type LocaleInfo struct {
+	Tag    language.Tag
+	Locale string
+	// NativeName is the locale name in the locale itself, e.g.: `italiano`.
+	NativeName string
+	// EnglishName is the locale name in English, e.g.: `Italian`.
+	EnglishName string
+	// Script is the ISO 15924 script code, e.g.: `Latn` or `Arab`.
+	Script    string
+	Direction Direction
+}
+
+// Direction return the text direction of tag,
+// derived from its script (explicit or most likely).
+func (i18n *I18n) Direction(tag language.Tag) Direction {
+	return direction(tag)
+}
+
+func direction(tag language.Tag) Direction {
+	if script, _ := tag.Script(); rtlScripts[script.String()] {
+		return DirectionRTL
+	}
+	return DirectionLTR
+}
+
+// LocaleInfo return the LocaleInfo of tag.
+func (i18n *I18n) LocaleInfo(tag language.Tag) LocaleInfo {
+	script, _ := tag.Script()
+	return LocaleInfo{
+		Tag:         tag,
+		Locale:      tag.String(),
+		NativeName:  display.Self.Name(tag),
+		EnglishName: display.English.Tags().Name(tag),
+		Script:      script.String(),
+		Direction:   direction(tag),
+	}
+}
+
+// LocalesInfo return the LocaleInfo of every i18n.Tags,
+// in the same order.
+func (i18n *I18n) LocalesInfo() []LocaleInfo {
+	infos := make([]LocaleInfo, len(i18n.Tags))
+	for i, tag := range i18n.Tags {
+		infos[i] = i18n.LocaleInfo(tag)
+	}
+	return infos
+}
@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoLocales is returned when i18n.Config.Locales is empty.
+var ErrNoLocales = errors.New("i18n.Locales can't be left empty, at least one locale must be provided")
+
+// LocaleParseError is returned when one
+// of i18n.Config.Locales can't be parsed.
+type LocaleParseError struct {
+	Locale string
+	Err    error
+}
+
+func (e *LocaleParseError) Error() string {
+	return fmt.Sprintf("can't parse locale identifier '%s': %s", e.Locale, e.Err.Error())
+}
+
+func (e *LocaleParseError) Unwrap() error {
+	return e.Err
+}
+
+// LocalizationFileError is returned when the localization
+// file for a given locale can't be found or parsed.
+type LocalizationFileError struct {
+	Locale string
+	Path   string
+	Err    error
+}
+
+func (e *LocalizationFileError) Error() string {
+	return fmt.Sprintf("can't load localization file '%s' for locale '%s': %s", e.Path, e.Locale, e.Err.Error())
+}
+
+func (e *LocalizationFileError) Unwrap() error {
+	return e.Err
+}
+
+// LocalizationFilesError aggregates all the
+// localization files errors, one for any failed locale.
+// Use errors.As to retrieve the first *LocalizationFileError.
+type LocalizationFilesError []*LocalizationFileError
+
+func (e LocalizationFilesError) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fileErr := range e {
+		messages = append(messages, fileErr.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// As finds the first file error matching target,
+// it works with errors.As since Go 1.13.
+func (e LocalizationFilesError) As(target interface{}) bool {
+	for _, fileErr := range e {
+		if errors.As(fileErr, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Is reports whether any of the file errors matches target.
+func (e LocalizationFilesError) Is(target error) bool {
+	for _, fileErr := range e {
+		if errors.Is(fileErr, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -23,15 +23,25 @@ func main() {
 
 	http.HandleFunc("/other", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
-		response := []byte(localizer.AutoTP(r, true, "GEM", "Marco"))
+		response := []byte(localizer.AutoTP(r, "ITEMS", 2))
 		_, _ = w.Write(response)
 	})
 
 	// http://localhost:8888/manual?plural=true
 	http.HandleFunc("/manual", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
-		plural := r.FormValue("plural") == "true"
-		response := []byte(localizer.TP("it", plural, "GEM", "Marco"))
+		key := "GEM"
+		if r.FormValue("plural") == "true" {
+			key = "GEM.plural"
+		}
+		response := []byte(localizer.T("it", key, "Marco"))
+		_, _ = w.Write(response)
+	})
+
+	// http://localhost:8888/welcome?gender=female
+	http.HandleFunc("/welcome", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		response := []byte(localizer.AutoTS(r, "WELCOME", r.FormValue("gender"), "Marco"))
 		_, _ = w.Write(response)
 	})
 
@@ -47,6 +57,7 @@ func main() {
 	fmt.Println("Try: http://localhost:8888/one")
 	fmt.Println("Try: http://localhost:8888/other")
 	fmt.Println("Try: http://localhost:8888/manual?plural=true")
+	fmt.Println("Try: http://localhost:8888/welcome?gender=female")
 	fmt.Println("Try: http://localhost:8888/")
 
 	log.Fatal(http.ListenAndServe(":8888", nil))
@@ -0,0 +1,116 @@
+package i18n
+
+import (
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// currencyPattern describe where the currency
+// symbol goes for a given language.
+type currencyPattern struct {
+	suffix bool
+	space  bool
+}
+
+// currencyPatterns holds the CLDR standard currency pattern
+// of the most common languages and regional variants,
+// unlisted languages use the `¤#,##0.00` pattern (e.g.: `€1,234.56`).
+var currencyPatterns = map[string]currencyPattern{
+	"bg":     {suffix: true, space: true},
+	"cs":     {suffix: true, space: true},
+	"da":     {suffix: true, space: true},
+	"de":     {suffix: true, space: true},
+	"de-AT":  {space: true},
+	"de-CH":  {space: true},
+	"de-LI":  {space: true},
+	"el":     {suffix: true, space: true},
+	"es":     {suffix: true, space: true},
+	"es-419": {},
+	"fi":     {suffix: true, space: true},
+	"fr":     {suffix: true, space: true},
+	"hr":     {suffix: true, space: true},
+	"hu":     {suffix: true, space: true},
+	"it":     {suffix: true, space: true},
+	"it-CH":  {space: true},
+	"lt":     {suffix: true, space: true},
+	"nb":     {suffix: true, space: true},
+	"nl":     {space: true},
+	"pl":     {suffix: true, space: true},
+	"pt":     {space: true},
+	"pt-PT":  {suffix: true, space: true},
+	"ro":     {suffix: true, space: true},
+	"ru":     {suffix: true, space: true},
+	"sk":     {suffix: true, space: true},
+	"sl":     {suffix: true, space: true},
+	"sv":     {suffix: true, space: true},
+	"uk":     {suffix: true, space: true},
+}
+
+// FormatNumber format n (any integer or float type) using
+// the locale decimal and grouping separators,
+// e.g.: 1234.5 -> `1,234.5` (en) or `1.234,5` (it).
+// Optionally pass number.Option(s) to set the scale, precision, etc...
+func (i18n *I18n) FormatNumber(locale string, n interface{}, options ...number.Option) string {
+	tag := i18n.MatchAvailableLanguageTag(locale)
+	return formatNumber(tag, n, options...)
+}
+
+// FormatPercent format n (any integer or float type) as a percentage,
+// e.g.: 0.25 -> `25%` (en) or `25 %` (de).
+// Optionally pass number.Option(s) to set the scale, precision, etc...
+func (i18n *I18n) FormatPercent(locale string, n interface{}, options ...number.Option) string {
+	tag := i18n.MatchAvailableLanguageTag(locale)
+	return message.NewPrinter(tag).Sprint(number.Percent(n, options...))
+}
+
+// FormatCurrency format amount in the given currency unit
+// with its standard scale and the locale symbol and pattern,
+// e.g.: 1234.56 EUR -> `€1,234.56` (en) or `1.234,56 €` (it).
+func (i18n *I18n) FormatCurrency(locale string, amount float64, unit currency.Unit) string {
+	tag := i18n.MatchAvailableLanguageTag(locale)
+	return formatCurrency(tag, amount, unit)
+}
+
+func formatNumber(tag language.Tag, n interface{}, options ...number.Option) string {
+	return message.NewPrinter(tag).Sprint(number.Decimal(n, options...))
+}
+
+func formatCurrency(tag language.Tag, amount float64, unit currency.Unit) string {
+	printer := message.NewPrinter(tag)
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	scale, _ := currency.Standard.Rounding(unit)
+	value := printer.Sprint(number.Decimal(amount, number.Scale(scale)))
+	symbol := printer.Sprint(currency.Symbol(unit))
+
+	pattern := currencyPatternForTag(tag)
+
+	separator := ""
+	if pattern.space {
+		separator = "\u00a0"
+	}
+
+	if pattern.suffix {
+		return sign + value + separator + symbol
+	}
+	return sign + symbol + separator + value
+}
+
+// currencyPatternForTag return the currency pattern of tag,
+// falling back to its parents and then to its base language.
+func currencyPatternForTag(tag language.Tag) currencyPattern {
+	for t := tag; t != language.Und; t = t.Parent() {
+		if pattern, ok := currencyPatterns[t.String()]; ok {
+			return pattern
+		}
+	}
+	base, _ := tag.Base()
+	return currencyPatterns[base.String()]
+}
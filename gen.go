@@ -0,0 +1,419 @@
+//go:build ignore
+// +build ignore
+
+// gen.go generates tables.go, the CLDR calendar and relative time
+// data used to format dates for all the CLDR locales.
+//
+// The data is extracted from the golang.org/x/text/date CLDR tree,
+// using the golang.org/x/text version required in go.mod:
+//
+//	go run gen.go
+//
+// A locale is generated only if its data differ from the data
+// cldrLocaleForTag would return without it (its parent's one),
+// so that regional variants such as en-AU or sr-Latn are covered
+// without duplicating the ones identical to their parent.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Path values of the golang.org/x/text/date tree.
+const (
+	calendars = 0
+	fields    = 1
+
+	gregorian = 7
+
+	months          = 0
+	days            = 1
+	dayPeriods      = 3
+	eras            = 4
+	dateFormats     = 5
+	timeFormats     = 6
+	dateTimeFormats = 7
+
+	formatContext = 0
+	standAlone    = 1
+
+	widthAbbreviated = 0
+	widthWide        = 2
+
+	am = 0
+	pm = 1
+
+	relative     = 1
+	relativeTime = 2
+
+	before1 = 0
+	current = 1
+	after1  = 2
+
+	future = 0
+	past   = 1
+)
+
+// lengths in DateStyle order: short, medium, long, full.
+var lengths = []uint16{0, 3, 1, 2}
+
+// relativeFields in relativeUnit order: second, minute, hour, day, week, month, year.
+var relativeFields = []uint16{60, 57, 54, 18, 12, 9, 3}
+
+// pluralCounts maps the tree plural counts
+// (other, one, zero, two, few, many) to plural.Form.
+var pluralCounts = []int{0, 2, 1, 3, 4, 5}
+
+const (
+	inheritOffsetShift        = 12
+	inheritMask        uint16 = 0x8000
+	inheritValueMask   uint16 = 0x0FFF
+
+	missingValue uint16 = 0xFFFF
+)
+
+type tree struct {
+	locales []uint32
+	indices []uint16
+	buckets []string
+}
+
+// lookup is a port of golang.org/x/text/internal/cldrtree.Tree.Lookup.
+func (t *tree) lookup(tag language.Tag, path ...uint16) string {
+	path = append([]uint16(nil), path...)
+	origTag := tag
+outer:
+	for {
+		id, _ := language.CompactIndex(tag)
+		index := t.indices[t.locales[id]:]
+
+		k := uint16(0)
+		for i := range path {
+			max := index[k]
+			if i < len(path)-1 {
+				if path[i] >= max {
+					break
+				}
+				k = index[k+1+path[i]]
+				if k == 0 {
+					break
+				}
+				if v := k &^ inheritMask; k != v {
+					offset := v >> inheritOffsetShift
+					value := v & inheritValueMask
+					path[uint16(i)-offset] = value
+					tag = origTag
+					continue outer
+				}
+			} else {
+				offset := missingValue
+				if path[i] < max {
+					offset = index[k+2+path[i]]
+				}
+				if offset == missingValue {
+					break
+				}
+				data := t.buckets[index[k+1]]
+				n := uint16(data[offset])
+				return data[offset+1 : offset+n+1]
+			}
+		}
+		if tag == language.Und {
+			break
+		}
+		tag = tag.Parent()
+	}
+	return ""
+}
+
+func main() {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "golang.org/x/text").Output()
+	if err != nil {
+		log.Fatal(err)
+	}
+	textDir := strings.TrimSpace(string(out))
+
+	t, cldrVersion := parseTree(filepath.Join(textDir, "date", "tables.go"))
+	tags := compactTags(filepath.Join(textDir, "internal", "language", "compact", "tables.go"))
+
+	// parents first, so that a locale is compared
+	// with the data it would inherit
+	sort.Slice(tags, func(i, j int) bool {
+		if di, dj := depth(tags[i]), depth(tags[j]); di != dj {
+			return di < dj
+		}
+		if li, lj := len(tags[i].String()), len(tags[j].String()); li != lj {
+			return li < lj
+		}
+		return tags[i].String() < tags[j].String()
+	})
+
+	generated := map[string]string{}
+	for _, tag := range tags {
+		if data := localeData(t, tag); data != generated[resolve(generated, tag)] {
+			generated[tag.String()] = data
+		}
+	}
+
+	locales := make([]string, 0, len(generated))
+	for locale := range generated {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by running \"go generate\" in github.com/oblq/i18n. DO NOT EDIT.\n\n")
+	buf.WriteString("package i18n\n\n")
+	fmt.Fprintf(&buf, "// cldrVersion is the CLDR version from which the tables are derived.\n")
+	fmt.Fprintf(&buf, "const cldrVersion = %q\n\n", cldrVersion)
+	buf.WriteString("var cldrLocales = map[string]*cldrLocale{\n")
+	for _, locale := range locales {
+		fmt.Fprintf(&buf, "%q: {\n%s},\n", locale, generated[locale])
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = ioutil.WriteFile("tables.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// resolve return the locale cldrLocaleForTag would
+// use for tag, given the generated locales.
+func resolve(generated map[string]string, tag language.Tag) string {
+	for t := tag; t != language.Und; t = t.Parent() {
+		if _, ok := generated[t.String()]; ok {
+			return t.String()
+		}
+	}
+	if base, _ := tag.Base(); base.String() != "und" {
+		if _, ok := generated[base.String()]; ok {
+			return base.String()
+		}
+	}
+	return "en"
+}
+
+// depth return the number of ancestors of tag.
+func depth(tag language.Tag) int {
+	n := 0
+	for ; tag != language.Und; tag = tag.Parent() {
+		n++
+	}
+	return n
+}
+
+// localeData return the cldrLocale fields of tag.
+func localeData(t *tree, tag language.Tag) string {
+	cal := func(path ...uint16) string {
+		return t.lookup(tag, append([]uint16{calendars, gregorian}, path...)...)
+	}
+
+	buf := &bytes.Buffer{}
+
+	writeStrings := func(name string, count uint16, path func(i uint16) string) {
+		fmt.Fprintf(buf, "%s: [%d]string{", name, count)
+		for i := uint16(0); i < count; i++ {
+			fmt.Fprintf(buf, "%q, ", path(i))
+		}
+		buf.WriteString("},\n")
+	}
+
+	for _, m := range []struct {
+		name           string
+		context, width uint16
+	}{
+		{"monthsAbbreviated", formatContext, widthAbbreviated},
+		{"monthsWide", formatContext, widthWide},
+		{"standAloneMonthsAbbreviated", standAlone, widthAbbreviated},
+		{"standAloneMonthsWide", standAlone, widthWide},
+	} {
+		writeStrings(m.name, 12, func(i uint16) string { return cal(months, m.context, m.width, i+1) })
+	}
+
+	for _, d := range []struct {
+		name           string
+		context, width uint16
+	}{
+		{"daysAbbreviated", formatContext, widthAbbreviated},
+		{"daysWide", formatContext, widthWide},
+		{"standAloneDaysAbbreviated", standAlone, widthAbbreviated},
+		{"standAloneDaysWide", standAlone, widthWide},
+	} {
+		writeStrings(d.name, 7, func(i uint16) string { return cal(days, d.context, d.width, i) })
+	}
+
+	writeStrings("dayPeriods", 2, func(i uint16) string {
+		return cal(dayPeriods, formatContext, widthAbbreviated, []uint16{am, pm}[i], 0)
+	})
+	writeStrings("eras", 2, func(i uint16) string { return cal(eras, widthAbbreviated, 0, i) })
+
+	writeStrings("dateFormats", 4, func(i uint16) string { return cal(dateFormats, lengths[i], 0) })
+	writeStrings("timeFormats", 4, func(i uint16) string { return cal(timeFormats, lengths[i], 0) })
+	writeStrings("dateTimeFormats", 4, func(i uint16) string { return cal(dateTimeFormats, lengths[i], 0) })
+
+	buf.WriteString("relative: [numRelativeUnits]cldrRelative{\n")
+	for _, field := range relativeFields {
+		rel := func(path ...uint16) string {
+			return t.lookup(tag, append([]uint16{fields, field}, path...)...)
+		}
+		fmt.Fprintf(buf, "{previous: %q, current: %q, next: %q,\n",
+			rel(relative, before1), rel(relative, current), rel(relative, after1))
+		for _, direction := range []struct {
+			name string
+			path uint16
+		}{{"future", future}, {"past", past}} {
+			var forms [6]string
+			for count, form := range pluralCounts {
+				forms[form] = rel(relativeTime, direction.path, uint16(count))
+			}
+			fmt.Fprintf(buf, "%s: [6]string{", direction.name)
+			for _, form := range forms {
+				fmt.Fprintf(buf, "%q, ", form)
+			}
+			buf.WriteString("},\n")
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("},\n")
+
+	return buf.String()
+}
+
+// compactIndex matches the golang.org/x/text/internal/language/compact
+// index constants names, e.g.: `srLatnBAIndex`.
+var compactIndex = regexp.MustCompile(`^([a-z]{2,3})([A-Z][a-z]{3})?([A-Z]{2}|[0-9]{3})?Index$`)
+
+// compactTags return the tags of the golang.org/x/text compact
+// index, the ones the date tree holds data for.
+func compactTags(path string) []language.Tag {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var tags []language.Tag
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Values) != 1 {
+				continue
+			}
+			m := compactIndex.FindStringSubmatch(vs.Names[0].Name)
+			if m == nil || m[1] == "und" {
+				continue
+			}
+			locale := m[1]
+			for _, subtag := range m[2:] {
+				if len(subtag) > 0 {
+					locale += "-" + subtag
+				}
+			}
+			tag := language.MustParse(locale)
+			if tag.String() != locale { // deprecated, e.g.: `in` is `id`
+				continue
+			}
+			if index, exact := language.CompactIndex(tag); !exact || uint64(index) != intValue(vs.Values[0]) {
+				log.Fatalf("unexpected compact index for %s", locale)
+			}
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// parseTree parse the golang.org/x/text/date tables.go source file.
+func parseTree(path string) (*tree, string) {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var t tree
+	var cldrVersion string
+	bucketNames := []string{}
+	buckets := map[string]string{}
+
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Values) != 1 {
+				continue
+			}
+			name := vs.Names[0].Name
+			switch {
+			case name == "CLDRVersion":
+				cldrVersion = stringValue(vs.Values[0])
+			case name == "locales":
+				for _, v := range vs.Values[0].(*ast.CompositeLit).Elts {
+					t.locales = append(t.locales, uint32(intValue(v)))
+				}
+			case name == "indices":
+				for _, v := range vs.Values[0].(*ast.CompositeLit).Elts {
+					t.indices = append(t.indices, uint16(intValue(v)))
+				}
+			case name == "buckets":
+				for _, v := range vs.Values[0].(*ast.CompositeLit).Elts {
+					bucketNames = append(bucketNames, v.(*ast.Ident).Name)
+				}
+			case strings.HasPrefix(name, "bucket"):
+				buckets[name] = stringValue(vs.Values[0])
+			}
+		}
+	}
+
+	for _, name := range bucketNames {
+		t.buckets = append(t.buckets, buckets[name])
+	}
+	return &t, cldrVersion
+}
+
+func intValue(expr ast.Expr) uint64 {
+	v, err := strconv.ParseUint(expr.(*ast.BasicLit).Value, 0, 32)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return v
+}
+
+// stringValue evaluates a string literal or a concatenation of string literals.
+func stringValue(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		s, err := strconv.Unquote(e.Value)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return s
+	case *ast.BinaryExpr:
+		return stringValue(e.X) + stringValue(e.Y)
+	}
+	log.Fatalf("unexpected expression %T", expr)
+	return ""
+}
@@ -2,8 +2,10 @@ package i18n
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"path/filepath"
+	"sync"
 
 	"github.com/oblq/swap"
 	"golang.org/x/text/language"
@@ -15,6 +17,19 @@ const (
 	HTTPLocalePositionIDHeader HTTPLocalePositionID = "header"
 	HTTPLocalePositionIDCookie HTTPLocalePositionID = "cookie"
 	HTTPLocalePositionIDQuery  HTTPLocalePositionID = "query"
+	// HTTPLocalePositionIDPath is the first URL path segment,
+	// e.g.: `/it/products`, the Key is ignored.
+	HTTPLocalePositionIDPath HTTPLocalePositionID = "path"
+	// HTTPLocalePositionIDHost is the first host label,
+	// e.g.: `it.example.com`, the Key is ignored.
+	HTTPLocalePositionIDHost HTTPLocalePositionID = "host"
+	// HTTPLocalePositionIDJWT is the Key claim of the
+	// `Authorization: Bearer <token>` JWT, see JWTSource.
+	HTTPLocalePositionIDJWT HTTPLocalePositionID = "jwt"
+	// HTTPLocalePositionIDUser is the locale of the LocaleUser
+	// found in the request context with the Key string key,
+	// see UserSource to use a collision-free key type.
+	HTTPLocalePositionIDUser HTTPLocalePositionID = "user"
 )
 
 type HTTPLocalePosition struct {
@@ -28,22 +43,60 @@ var DefaultHTTPLookUpStrategy = []HTTPLocalePosition{
 	{HTTPLocalePositionIDQuery, "lang"},
 }
 
+// Logger is the interface used to report diagnostics,
+// the standard library *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
 // Config is the i18n config struct.
 //
 // The Locales order is important,
 // they must be ordered from the most preferred to te least one,
 // the first one is the default.
 //
-// Set Path OR Locs, Path takes precedence over Locs.
 // Set Locs if you want to use hardcoded localizations,
 // useful to embed i18n in other library packages.
-// Otherwise, set Path to load localization files.
+// Set Path to load localization files.
+// When both are set the localization files override
+// the hardcoded localizations key by key, see Layer.
 type Config struct {
 	// HTTPLookUpStrategy represent the strategy to extract the language from the request.
 	// The order of element is important, the first one is the default.
 	// Default is DefaultMiddlewareLookUpStrategy.
 	HTTPLookUpStrategy []HTTPLocalePosition
 
+	// LocaleSources, if set, replace HTTPLookUpStrategy
+	// with an ordered chain of LocaleSource, so that
+	// HTTPLocalePosition can be composed with custom sources
+	// (e.g.: user profile or command line flag), see LocaleSource.
+	LocaleSources []LocaleSource `json:"-" yaml:"-" toml:"-"`
+
+	// JWTVerifier verify the tokens read by the
+	// HTTPLocalePositionIDJWT positions, see JWTSource.
+	JWTVerifier JWTVerifier `json:"-" yaml:"-" toml:"-"`
+
+	// JWTSecret is the HMAC secret used to verify the tokens read
+	// by the HTTPLocalePositionIDJWT positions if JWTVerifier is nil.
+	// Tokens are ignored if none of them is set.
+	JWTSecret string
+
+	// MinConfidence is the minimum language.Confidence
+	// (language.No, language.Low, language.High or language.Exact)
+	// a locale must be matched with to be accepted.
+	// When a locale found in the request is matched with a lower
+	// confidence the look-up continues with the next candidate,
+	// the default locale is used if none is accepted.
+	// Request locales are never accepted with language.No confidence,
+	// so the default (language.No) is the same as language.Low for requests.
+	MinConfidence language.Confidence
+
+	// PseudoLocales enable the PseudoLocaleAccented (en-XA)
+	// and PseudoLocaleBidi (ar-XB) pseudo-locales, generated
+	// from the default locale, e.g.: `?lang=en-XA`.
+	// Useful for UI testing, do not enable it in production.
+	PseudoLocales bool
+
 	// Locales order is important, the first one is the default,
 	// they must be ordered from the most preferred to te least one.
 	// A localization file for any given locale must be provided.
@@ -55,10 +108,23 @@ type Config struct {
 	// Files will be searched automatically based on Locales.
 	Path string
 
+	// NamespacesLayout is the layout of the namespaces
+	// localization files in Path, see Namespace.
+	// Default is NamespacesLayoutNamespaceDir.
+	NamespacesLayout NamespacesLayout
+
 	// Locs contains hardcoded localizations.
 	// Use it if you want to use hardcoded localizations,
 	// useful to embed i18n in other library packages.
 	Locs map[string]map[string]string
+
+	// Layers are additional localizations sources merged,
+	// in the declared order, over Locs and Path, see Layer.
+	Layers []Layer
+
+	// Logger is used to report diagnostics,
+	// nothing is logged if nil.
+	Logger Logger `json:"-" yaml:"-" toml:"-"`
 }
 
 // I18n is the i18n instance.
@@ -81,8 +147,23 @@ type I18n struct {
 	// Automatically generated using Config.Locales.
 	matcher language.Matcher
 
-	// localizations[<language>][<key>] -> Localization
-	localizations map[string]map[string]string
+	// locales are the Tags strings.
+	locales []string
+
+	// layers are the localizations stores,
+	// from the highest precedence to the lowest.
+	layers layers
+
+	// messages caches the compiled localizations.
+	messages messageCache
+
+	// namespaces[<namespace>][<language>][<key>] -> Localization,
+	// lazily loaded on first use.
+	namespaces map[string]MapStore
+	// knownNamespaces are the namespaces found in Config.Path
+	// or declared using Namespace, keys are split only on them.
+	knownNamespaces map[string]bool
+	namespacesMutex sync.RWMutex
 
 	// localizedHandlers is used by the FileServer
 	localizedHandlers map[string]http.Handler
@@ -109,7 +190,7 @@ func NewWithConfigFile(configFilePath string) (*I18n, error) {
 	}
 
 	if err := swap.Parse(i18n.Config, configFilePath); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("can't parse config file '%s': %w", configFilePath, err)
 	}
 
 	if err := i18n.setup(); err != nil {
@@ -148,19 +229,33 @@ func (i18n *I18n) setup() error {
 	}
 
 	if len(i18n.Config.Locales) == 0 {
-		return errors.New("i18n.Locales can't be left empty, at least one locale must be provided")
+		return ErrNoLocales
 	}
 
-	i18n.Tags = parseLocalesToTags(i18n.Config.Locales)
+	tags, err := parseLocalesToTags(i18n.Config.Locales)
+	if err != nil {
+		return fmt.Errorf("i18n setup failed: %w", err)
+	}
+
+	i18n.Tags = tags
 	i18n.matcher = language.NewMatcher(i18n.Tags)
+	i18n.locales = make([]string, len(tags))
+	for i, tag := range tags {
+		i18n.locales[i] = tag.String()
+	}
 
-	if i18n.Config.Locs != nil {
-		i18n.localizations = i18n.Config.Locs
-		return nil
-	} else if len(i18n.Config.Path) > 0 {
-		return i18n.LoadLocalizationFiles(i18n.Config.Path)
+	if err = i18n.loadLayers(); err != nil {
+		return err
 	}
 
+	i18n.namespacesMutex.Lock()
+	i18n.knownNamespaces = i18n.findNamespaces()
+	i18n.namespacesMutex.Unlock()
+
+	i18n.precompile("", &i18n.layers)
+	i18n.layers.Watch(func() {
+		i18n.precompile("", &i18n.layers)
+	})
 	return nil
 }
 
@@ -168,18 +263,47 @@ func (i18n *I18n) setup() error {
 // localization files in i18n.Config.LocalizationsPath for the given i18n.Tags,
 // localization files must be named as the <language.Tag>.String()
 // (locale, e.g.: `en.yml` for `language.English`).
-func (i18n *I18n) LoadLocalizationFiles(localizationsPath string) (err error) {
-	i18n.localizations = make(map[string]map[string]string)
-
-	for _, lang := range i18n.Tags {
-		var langLocalizations map[string]string
-		locFileName := filepath.Join(localizationsPath, lang.String())
-		if err := swap.Parse(&langLocalizations, locFileName); err != nil {
-			return err
+// All the files are loaded anyway, failures are returned
+// together as LocalizationFilesError.
+// The loaded localizations replace all the layers.
+func (i18n *I18n) LoadLocalizationFiles(localizationsPath string) error {
+	localizations, err := loadLocalizationFiles(i18n.Tags, func(locale string) string {
+		return filepath.Join(localizationsPath, locale)
+	})
+	i18n.layers = layers{{name: LayerPath, store: localizations}}
+	i18n.precompile("", &i18n.layers)
+	return err
+}
+
+// flatten copy the nested localizations into flat,
+// nested keys are joined by keySeparator, so that
+// `WELCOME: {female: "Benvenuta"}` becomes `WELCOME.female: "Benvenuta"`.
+func flatten(prefix string, nested map[string]interface{}, flat map[string]string) {
+	for key, value := range nested {
+		if len(prefix) > 0 {
+			key = prefix + keySeparator + key
 		}
 
-		i18n.localizations[lang.String()] = langLocalizations
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flatten(key, v, flat)
+		case map[interface{}]interface{}:
+			stringKeys := make(map[string]interface{}, len(v))
+			for k, vv := range v {
+				stringKeys[fmt.Sprint(k)] = vv
+			}
+			flatten(key, stringKeys, flat)
+		case nil:
+			flat[key] = ""
+		default:
+			flat[key] = fmt.Sprint(v)
+		}
 	}
+}
 
-	return
+// logf report diagnostics using i18n.Config.Logger, if any.
+func (i18n *I18n) logf(format string, v ...interface{}) {
+	if i18n.Config != nil && i18n.Config.Logger != nil {
+		i18n.Config.Logger.Printf("[i18n] "+format, v...)
+	}
 }
@@ -1,16 +1,30 @@
 package i18n
 
 import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
+	"text/template"
 	"time"
 
 	"github.com/oblq/swap"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/currency"
 	"golang.org/x/text/language"
+	"golang.org/x/text/number"
 )
 
 const (
@@ -87,7 +101,6 @@ func TestNewWithConfigFile(t *testing.T) {
 }
 
 func TestNewWithConfigWithLocs(t *testing.T) {
-	// Will throw `log.Fatal()` if an error occour.
 	locConfigMap, err := NewWithConfig(&Config{
 		Locales: []string{
 			language.English.String(),
@@ -301,3 +314,778 @@ func TestMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestMinConfidence(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales:       []string{language.English.String(), language.Italian.String()},
+		Locs:          hardcodedLocs,
+		MinConfidence: language.High,
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, language.Italian, localizer.MatchAvailableLanguageTag("it-IT"))
+	assert.Equal(t, language.English, localizer.MatchAvailableLanguageTag("ja"))
+
+	// unmatched header, the look-up continues to the cookie
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Add("Accept-Language", "ja")
+	r.AddCookie(&http.Cookie{Name: "lang", Value: "it"})
+	assert.Equal(t, "it", localizer.GetLocale(r))
+
+	// without MinConfidence an unmatched value is skipped anyway
+	localizer.Config.MinConfidence = language.No
+	assert.Equal(t, "it", localizer.GetLocale(r))
+
+	// every Accept-Language preference is a candidate
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Add("Accept-Language", "ja, de;q=0.9, it;q=0.5")
+	r.AddCookie(&http.Cookie{Name: "lang", Value: "en"})
+	assert.Equal(t, "it", localizer.GetLocale(r))
+
+	r.Header.Set("Accept-Language", "ja, de;q=0.9")
+	assert.Equal(t, "en", localizer.GetLocale(r))
+}
+
+func TestErrorsAndLogger(t *testing.T) {
+	_, err := NewWithConfig(&Config{
+		Locales: []string{"en", "not a locale"},
+		Locs:    hardcodedLocs,
+	})
+	var parseErr *LocaleParseError
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, "not a locale", parseErr.Locale)
+
+	_, err = NewWithConfig(&Config{Locales: []string{}})
+	assert.True(t, errors.Is(err, ErrNoLocales))
+
+	_, err = NewWithConfig(&Config{
+		Locales: []string{"en", "it", "de", "fr"},
+		Path:    "./example/i18n",
+	})
+	var filesErr LocalizationFilesError
+	assert.True(t, errors.As(err, &filesErr))
+	assert.Equal(t, 2, len(filesErr))
+	var fileErr *LocalizationFileError
+	assert.True(t, errors.As(err, &fileErr))
+	assert.Equal(t, "de", fileErr.Locale)
+	assert.Equal(t, "example/i18n/de", fileErr.Path)
+	assert.True(t, errors.Is(err, fileErr))
+	assert.True(t, errors.Is(filesErr, filesErr[1].Err))
+	assert.False(t, errors.Is(err, ErrNoLocales))
+
+	var buf bytes.Buffer
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String()},
+		Locs:    hardcodedLocs,
+		Logger:  log.New(&buf, "", 0),
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, GEM, localizer.AutoT(nil, GEM))
+	assert.Equal(t, "[i18n] http request nil, key: GEM\n", buf.String())
+}
+
+func TestLocalizeError(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Locs:    hardcodedLocs,
+	})
+	assert.Equal(t, nil, err)
+
+	domainErr := localizer.NewError(GEM, "Marco")
+	assert.Equal(t, "Something went wrong, please try again later Marco", domainErr.Error())
+
+	wrapped := fmt.Errorf("saving user: %w", domainErr)
+	assert.Equal(t,
+		"Qualcosa è andato storto, riprova più tardi Marco",
+		localizer.LocalizeError("it", wrapped))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Add("Accept-Language", "it")
+	assert.Equal(t,
+		"Qualcosa è andato storto, riprova più tardi Marco",
+		localizer.AutoLocalizeError(r, wrapped))
+
+	plainErr := errors.New("plain")
+	assert.Equal(t, "plain", localizer.LocalizeError("it", plainErr))
+	assert.True(t, errors.Is(&Error{Key: GEM, Err: plainErr}, plainErr))
+}
+
+func TestMessage(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Locs: map[string]map[string]string{
+			"en": {"NAME": "Marco", "HELLO": "Hello %s"},
+			"it": {"NAME": "Marco", "HELLO": "Ciao %s"},
+		},
+	})
+	assert.Equal(t, nil, err)
+
+	msg := localizer.NewMessage("HELLO", localizer.NewMessage("NAME"))
+	assert.Equal(t, "Hello Marco", msg.String())
+	assert.Equal(t, "Hello Marco", fmt.Sprint(msg))
+	assert.Equal(t, "Ciao Marco", localizer.Render("it", msg))
+	assert.Equal(t, "HELLO", Message{Key: "HELLO"}.String())
+
+	var buf bytes.Buffer
+	tpl := template.Must(template.New("").Funcs(localizer.FuncMap("it")).Parse(`{{ Render .Msg }} - {{ T "HELLO" "Bob" }}`))
+	assert.Equal(t, nil, tpl.Execute(&buf, struct{ Msg Message }{msg}))
+	assert.Equal(t, "Ciao Marco - Ciao Bob", buf.String())
+}
+
+func TestFormat(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Locs:    hardcodedLocs,
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, "1,234,567", localizer.FormatNumber("en", 1234567))
+	assert.Equal(t, "1.234.567", localizer.FormatNumber("it", 1234567))
+	assert.Equal(t, "1.234,50", localizer.FormatNumber("it", 1234.5, number.Scale(2)))
+	assert.Equal(t, "26%", localizer.FormatPercent("en", 0.256))
+	assert.Equal(t, "€1,234.56", localizer.FormatCurrency("en", 1234.56, currency.EUR))
+	assert.Equal(t, "1.234,56\u00a0€", localizer.FormatCurrency("it", 1234.56, currency.EUR))
+	assert.Equal(t, "-€3.00", localizer.FormatCurrency("en", -3, currency.EUR))
+	assert.Equal(t, "¥1,235", localizer.FormatCurrency("en", 1234.56, currency.JPY))
+
+	// regional variants override their base language pattern
+	assert.Equal(t, "1\u00a0234,56\u00a0€", formatCurrency(language.MustParse("pt-PT"), 1234.56, currency.EUR))
+	assert.Equal(t, "€\u00a01\u00a0234,56", formatCurrency(language.MustParse("de-AT"), 1234.56, currency.EUR))
+	assert.Equal(t, "$1,234.56", formatCurrency(language.MustParse("es-MX"), 1234.56, currency.MXN))
+	assert.Equal(t, "1.234,56\u00a0€", formatCurrency(language.MustParse("de-DE"), 1234.56, currency.EUR))
+
+	translator := localizer.Translator("it-IT")
+	assert.Equal(t, "it", translator.Locale())
+	assert.Equal(t,
+		"Qualcosa è andato storto, riprova più tardi 1.234,56\u00a0€",
+		translator.T(GEM, translator.FormatCurrency(1234.56, currency.EUR)))
+}
+
+func TestFormatDate(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String(), "ar", "pt-BR", "en-AU", "sr-Latn", "fr-CA"},
+		Locs:    hardcodedLocs,
+	})
+	assert.Equal(t, nil, err)
+
+	date := time.Date(2021, time.March, 4, 15, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, "3/4/21", localizer.FormatDate("en", date, DateStyleShort))
+	assert.Equal(t, "Mar 4, 2021", localizer.FormatDate("en", date, DateStyleMedium))
+	assert.Equal(t, "Thursday, March 4, 2021", localizer.FormatDate("en", date, DateStyleFull))
+	assert.Equal(t, "04/03/21", localizer.FormatDate("it", date, DateStyleShort))
+	assert.Equal(t, "giovedì 4 marzo 2021", localizer.FormatDate("it", date, DateStyleFull))
+	assert.Equal(t, "4 de março de 2021", localizer.FormatDate("pt-BR", date, DateStyleLong))
+
+	// regional variants follow the CLDR parent chain
+	assert.Equal(t, "4/3/21", localizer.FormatDate("en-AU", date, DateStyleShort))
+	assert.Equal(t, "3:04 pm", localizer.FormatTime("en-AU", date, DateStyleShort))
+	assert.Equal(t, "04. mart 2021.", localizer.FormatDate("sr-Latn", date, DateStyleLong))
+	assert.Equal(t, "15 h 04", localizer.FormatTime("fr-CA", date, DateStyleShort))
+
+	assert.Equal(t, "3:04 PM", localizer.FormatTime("en", date, DateStyleShort))
+	assert.Equal(t, "15:04:05 UTC", localizer.FormatTime("it", date, DateStyleLong))
+	assert.Equal(t, "March 4, 2021 at 3:04:05 PM UTC", localizer.FormatDateTime("en", date, DateStyleLong))
+	assert.Equal(t, "04/03/21, 15:04", localizer.FormatDateTime("it", date, DateStyleShort))
+
+	// unknown styles fall back to DateStyleMedium
+	assert.Equal(t, "Mar 4, 2021", localizer.FormatDate("en", date, DateStyle(42)))
+	assert.Equal(t, "3:04:05 PM", localizer.FormatTime("en", date, DateStyle(-1)))
+	assert.Equal(t, "Mar 4, 2021, 3:04:05 PM", localizer.FormatDateTime("en", date, DateStyle(4)))
+	assert.Equal(t, "4 mar 2021", localizer.Translator("it").FormatDate(date, DateStyle(4)))
+
+	tt := []struct {
+		d      time.Duration
+		en, it string
+	}{
+		{0, "now", "ora"},
+		{-3 * 24 * time.Hour, "3 days ago", "3 giorni fa"},
+		{2 * time.Hour, "in 2 hours", "tra 2 ore"},
+		{-24 * time.Hour, "yesterday", "ieri"},
+		{25 * time.Hour, "tomorrow", "domani"},
+		{-1 * time.Minute, "1 minute ago", "1 minuto fa"},
+		{-400 * 24 * time.Hour, "last year", "anno scorso"},
+		{3 * 365 * 24 * time.Hour, "in 3 years", "tra 3 anni"},
+		// rounded before picking the unit
+		{23*time.Hour + 59*time.Minute, "tomorrow", "domani"},
+		{-(23*time.Hour + 59*time.Minute), "yesterday", "ieri"},
+		{13 * 12 * time.Hour, "next week", "settimana prossima"},
+		{59*time.Minute + 45*time.Second, "in 1 hour", "tra 1 ora"},
+		{90 * time.Second, "in 2 minutes", "tra 2 minuti"},
+	}
+	for _, tc := range tt {
+		assert.Equal(t, tc.en, localizer.FormatRelativeTime("en", tc.d))
+		assert.Equal(t, tc.it, localizer.FormatRelativeTime("it", tc.d))
+	}
+
+	translator := localizer.Translator("it")
+	assert.Equal(t, "4 mar 2021", translator.FormatDate(date, DateStyleMedium))
+	assert.Equal(t, "3 giorni fa", translator.FormatRelativeTime(-72*time.Hour))
+}
+
+func TestFormatListAndUnits(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Locs:    hardcodedLocs,
+	})
+	assert.Equal(t, nil, err)
+
+	items := []string{"a", "b", "c"}
+	assert.Equal(t, "", localizer.FormatList("en", nil, ListStyleAnd))
+	assert.Equal(t, "a", localizer.FormatList("en", items[:1], ListStyleAnd))
+	assert.Equal(t, "a and b", localizer.FormatList("en", items[:2], ListStyleAnd))
+	assert.Equal(t, "a, b, and c", localizer.FormatList("en", items, ListStyleAnd))
+	assert.Equal(t, "a, b e c", localizer.FormatList("it", items, ListStyleAnd))
+	assert.Equal(t, "a, b o c", localizer.FormatList("it", items, ListStyleOr))
+	assert.Equal(t, "a, b, c, or d", localizer.FormatList("en", append(items, "d"), ListStyleOr))
+
+	assert.Equal(t, "5\u00a0km", localizer.FormatUnit("en", 5, UnitKilometer))
+	assert.Equal(t, "1,5\u00a0kg", localizer.FormatUnit("it", 1.5, UnitKilogram))
+	assert.Equal(t, "1 hour", localizer.FormatUnit("en", 1, UnitHour))
+	assert.Equal(t, "2 ore", localizer.FormatUnit("it", int64(2), UnitHour))
+
+	assert.Equal(t, "2 hours, 3 minutes", localizer.FormatDuration("en", 2*time.Hour+3*time.Minute))
+	assert.Equal(t, "1 giorno, 2 ore e 3 secondi", localizer.FormatDuration("it", 26*time.Hour+3*time.Second))
+	assert.Equal(t, "0 seconds", localizer.FormatDuration("en", 0))
+	assert.Equal(t, "2 ore e 3 minuti", localizer.Translator("it").FormatDuration(2*time.Hour+3*time.Minute))
+
+	// negative and float values
+	assert.Equal(t, "-2 giorni", localizer.FormatUnit("it", -2, UnitDay))
+	assert.Equal(t, "-1 hour", localizer.FormatUnit("en", -1, UnitHour))
+	assert.Equal(t, "1 hour", localizer.FormatUnit("en", 1.0, UnitHour))
+	assert.Equal(t, "-1 hour", localizer.FormatUnit("en", float32(-1), UnitHour))
+	assert.Equal(t, "1.5 hours", localizer.FormatUnit("en", 1.5, UnitHour))
+	assert.Equal(t, "1 hour", localizer.FormatUnit("en", 1.0001, UnitHour))
+	assert.Equal(t, "0,5 ore", localizer.FormatUnit("it", 0.5, UnitHour))
+	assert.Equal(t, "5 seconds", localizer.FormatDuration("en", -5*time.Second))
+	assert.Equal(t, "∞ hours", localizer.FormatUnit("en", math.Inf(1), UnitHour))
+
+	// unknown styles and units
+	assert.Equal(t, "a, b, and c", localizer.FormatList("en", items, ListStyle(7)))
+	assert.Equal(t, "a, b e c", localizer.FormatList("it", items, ListStyle(-1)))
+	assert.Equal(t, "1.234", localizer.FormatUnit("it", 1234, Unit(-1)))
+	assert.Equal(t, "5", localizer.FormatUnit("en", 5, numUnits))
+
+	// unsupported languages use the English patterns and names
+	assert.Equal(t, "a, b, and c", formatList(language.Dutch, items, ListStyleAnd))
+	assert.Equal(t, "2 hours, 3 minutes", formatDuration(language.Japanese, 2*time.Hour+3*time.Minute))
+	assert.Equal(t, "1.234,5\u00a0km", formatUnit(language.Dutch, 1234.5, UnitKilometer))
+}
+
+func TestVariants(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Path:    "./example/i18n",
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, "Benvenuta Anna", localizer.TS("it", "WELCOME", "female", "Anna"))
+	assert.Equal(t, "Benvenuto Marco", localizer.TS("it", "WELCOME", "male", "Marco"))
+	assert.Equal(t, "Ciao Alex", localizer.TS("it", "WELCOME", "", "Alex"))
+	assert.Equal(t, "Welcome Alex", localizer.TS("en", "WELCOME", "unknown", "Alex"))
+
+	assert.Equal(t, "1 item", localizer.TP("en", "ITEMS", 1))
+	assert.Equal(t, "2 items", localizer.TP("en", "ITEMS", 2))
+	assert.Equal(t, "0 elementi", localizer.TP("it", "ITEMS", 0))
+	assert.Equal(t,
+		"Some things went wrong, please try again later Marco",
+		localizer.TP("en", GEMPlural, "Marco"))
+
+	// select and plural variants together
+	localizer, err = NewWithConfig(&Config{
+		Locales: []string{language.Italian.String()},
+		Locs: map[string]map[string]string{
+			"it": {
+				"GUESTS.female.one":   "%d ospite arrivata",
+				"GUESTS.female.other": "%d ospiti arrivate",
+				"GUESTS.other.one":    "%d ospite arrivato",
+				"GUESTS.other.other":  "%d ospiti arrivati",
+			},
+		},
+	})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "3 ospiti arrivate", localizer.TS("it", "GUESTS", "female", 3))
+	assert.Equal(t, "1 ospite arrivato", localizer.TS("it", "GUESTS", "male", 1))
+}
+
+func TestContext(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Path:    "./example/i18n",
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, "Apri", localizer.T("it", "OPEN"))
+	assert.Equal(t, "Aperto", localizer.TC("it", "status", "OPEN"))
+	assert.Equal(t, "Apri", localizer.TC("it", "button", "OPEN"))
+	assert.Equal(t, "Open", localizer.TC("en", "status", "OPEN"))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Add("Accept-Language", "it")
+	assert.Equal(t, "Aperto", localizer.AutoTC(r, "status", "OPEN"))
+	assert.Equal(t, "Aperto", localizer.Translator("it").TC("status", "OPEN"))
+}
+
+func TestNamespace(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Path:    "./example/i18n",
+		Locs: map[string]map[string]string{
+			"en": {"Error: %s": "Error: %s!"},
+		},
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, "Paga 10€ ora", localizer.T("it", "checkout:PAY_NOW", "10€"))
+	assert.Equal(t, "Pay 10€ now", localizer.Namespace("checkout").T("en", "PAY_NOW", "10€"))
+	assert.Equal(t, "1 articolo nel carrello", localizer.Namespace("checkout").TP("it", "ITEMS", 1))
+	assert.Equal(t, "checkout:MISSING", localizer.T("it", "checkout:MISSING"))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Add("Accept-Language", "it")
+	assert.Equal(t, "2 articoli nel carrello", localizer.Namespace("checkout").AutoTP(r, "ITEMS", 2))
+
+	// keys with an unknown prefix are not namespaced
+	var logs bytes.Buffer
+	localizer.Config.Logger = log.New(&logs, "", 0)
+	assert.Equal(t, "Error: boom!", localizer.T("en", "Error: %s", "boom"))
+	assert.Equal(t, "missing:KEY", localizer.T("en", "missing:KEY"))
+	assert.Equal(t, "", logs.String())
+	assert.Equal(t, 1, len(localizer.namespaces))
+
+	assert.Equal(t, "missing:KEY", localizer.Namespace("missing").T("en", "KEY"))
+	assert.Equal(t, true, strings.Contains(logs.String(), "can't load namespace 'missing'"))
+
+	var filesErr LocalizationFilesError
+	assert.Equal(t, true, errors.As(localizer.LoadNamespace("missing"), &filesErr))
+	assert.Equal(t, nil, localizer.LoadNamespace("checkout"))
+
+	localizer.Config.NamespacesLayout = NamespacesLayoutLocaleDir
+	assert.NotEqual(t, nil, localizer.LoadNamespace("checkout"))
+}
+
+func TestLayers(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Locs: map[string]map[string]string{
+			"en": {GEM: "default", "DEFAULT_ONLY": "from defaults"},
+		},
+		Path: "./example/i18n",
+		Layers: []Layer{
+			{Name: "overrides", Locs: map[string]map[string]string{"it": {"OPEN": "Apri ora"}}},
+		},
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, "from defaults", localizer.T("en", "DEFAULT_ONLY"))
+	assert.Equal(t, "Something went wrong, please try again later Marco", localizer.T("en", GEM, "Marco"))
+	assert.Equal(t, "Apri ora", localizer.T("it", "OPEN"))
+
+	layer, ok := localizer.Origin("en", "DEFAULT_ONLY")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, LayerLocs, layer)
+	layer, _ = localizer.Origin("en", GEM)
+	assert.Equal(t, LayerPath, layer)
+	layer, _ = localizer.Origin("it", "OPEN")
+	assert.Equal(t, "overrides", layer)
+	_, ok = localizer.Origin("it", "DEFAULT_ONLY")
+	assert.Equal(t, false, ok)
+
+	_, err = NewWithConfig(&Config{
+		Locales: []string{language.English.String()},
+		Layers:  []Layer{{Name: "overrides", Path: "./missing"}},
+	})
+	var filesErr LocalizationFilesError
+	assert.Equal(t, true, errors.As(err, &filesErr))
+
+	// the files errors of every layer are returned
+	_, err = NewWithConfig(&Config{
+		Locales: []string{language.English.String()},
+		Path:    "./missing",
+		Layers:  []Layer{{Name: "overrides", Path: "./missing/overrides"}},
+	})
+	assert.Equal(t, true, errors.As(err, &filesErr))
+	assert.Equal(t, 2, len(filesErr))
+	assert.Equal(t, filepath.Join("missing", "en"), filesErr[0].Path)
+	assert.Equal(t, filepath.Join("missing", "overrides", "en"), filesErr[1].Path)
+}
+
+type testStore struct {
+	MapStore
+	onChange []func()
+}
+
+func (s *testStore) Watch(onChange func()) {
+	s.onChange = append(s.onChange, onChange)
+}
+
+func TestStore(t *testing.T) {
+	files, err := NewFileStore("./example/i18n", "en", "it")
+	assert.Equal(t, nil, err)
+	localization, ok := files.Lookup("it", "OPEN")
+	assert.Equal(t, true, ok)
+	assert.Equal(t, "Apri", localization)
+
+	_, err = NewFileStore("./example/i18n", "en", "es")
+	var filesErr LocalizationFilesError
+	assert.Equal(t, true, errors.As(err, &filesErr))
+
+	store := &testStore{MapStore: MapStore{"it": {"OPEN": "Apri subito"}}}
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Layers:  []Layer{{Name: "db", Store: store}, {Name: "files", Store: files}},
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, "Open", localizer.T("en", "OPEN"))
+	assert.Equal(t, "Apri", localizer.T("it", "OPEN"))
+
+	store.MapStore["it"]["OPEN.one"] = "Apri %d"
+	assert.Equal(t, "Apri 1", localizer.TP("it", "OPEN", 1))
+	layer, _ := localizer.Origin("it", "OPEN.one")
+	assert.Equal(t, "db", layer)
+
+	assert.Equal(t, files.Keys("en"), localizer.Store().Keys("en"))
+	assert.Equal(t, true, len(localizer.Store().Keys("it")) == len(files.Keys("it"))+1)
+
+	// the first watcher is the localizer one, recompiling the messages
+	changed := 0
+	localizer.Store().Watch(func() { changed++ })
+	assert.Equal(t, 2, len(store.onChange))
+	store.MapStore["it"]["OPEN"] = "Apri subito"
+	for _, onChange := range store.onChange {
+		onChange()
+	}
+	assert.Equal(t, 1, changed)
+}
+
+func benchmarkLocalizer(b *testing.B) *I18n {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Path:    "./example/i18n",
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return localizer
+}
+
+func BenchmarkT(b *testing.B) {
+	localizer := benchmarkLocalizer(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		localizer.T("it", "OPEN")
+	}
+}
+
+func BenchmarkTParams(b *testing.B) {
+	localizer := benchmarkLocalizer(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		localizer.T("en", GEM, "Marco")
+	}
+}
+
+func BenchmarkTP(b *testing.B) {
+	localizer := benchmarkLocalizer(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		localizer.TP("it", "ITEMS", 2)
+	}
+}
+
+func BenchmarkAutoT(b *testing.B) {
+	localizer := benchmarkLocalizer(b)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Add("Accept-Language", "it-IT,it;q=0.9,en;q=0.8")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		localizer.AutoT(r, GEM, "Marco")
+	}
+}
+
+func TestCompiledMessage(t *testing.T) {
+	for _, tc := range []struct {
+		format string
+		params []interface{}
+	}{
+		{"plain", nil},
+		{"", nil},
+		{"100%% %s", []interface{}{"sure"}},
+		{"%s has %d items, %v", []interface{}{"Anna", 3, 2.5}},
+		{"%d %s", []interface{}{"wrong", 1}},
+		{"%05.2f%%", []interface{}{3.14159}},
+		{"%[2]s %[1]s", []interface{}{"a", "b"}},
+		{"missing %s", nil},
+		{"extra", []interface{}{1}},
+		{"trailing %", nil},
+		{"%s", []interface{}{errors.New("err")}},
+	} {
+		assert.Equal(t, fmt.Sprintf(tc.format, tc.params...), compile(tc.format).render(tc.params), tc.format)
+	}
+}
+
+func TestPseudoLocales(t *testing.T) {
+	config := &Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Path:    "./example/i18n",
+	}
+	localizer, err := NewWithConfig(config)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "Open", localizer.T(PseudoLocaleAccented, "OPEN"))
+
+	config.PseudoLocales = true
+	assert.Equal(t, "[Öþéñ one]", localizer.T(PseudoLocaleAccented, "OPEN"))
+	assert.Equal(t, "[2 îţéɱš one]", localizer.TP(PseudoLocaleAccented, "ITEMS", 2))
+	assert.Equal(t, "\u200f\u202eOpen\u202c", localizer.T(PseudoLocaleBidi, "OPEN"))
+	assert.Equal(t, "Apri", localizer.T("it", "OPEN"))
+
+	r := httptest.NewRequest(http.MethodGet, "/?lang=en-XA", nil)
+	assert.Equal(t, PseudoLocaleAccented, localizer.GetLocale(r))
+	assert.Equal(t, "[Ĝéɱ %5.2f ĝéɱ one]", pseudoFormat("Gem %5.2f gem", pseudoAccented))
+	assert.Equal(t, "[100%% %s one]", pseudoFormat("100%% %s", pseudoAccented))
+	assert.Equal(t, "\u200f\u202e%[1]s\u202c \u202eago\u202c", pseudoFormat("%[1]s ago", pseudoBidi))
+}
+
+func TestDirection(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Arabic.String(), language.Hebrew.String()},
+		Locs:    map[string]map[string]string{},
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, DirectionLTR, localizer.Direction(language.English))
+	assert.Equal(t, DirectionRTL, localizer.Direction(language.Arabic))
+	assert.Equal(t, DirectionRTL, localizer.Direction(language.MustParse(PseudoLocaleBidi)))
+	assert.Equal(t, DirectionLTR, localizer.Direction(language.MustParse("az-Latn")))
+	assert.Equal(t, DirectionRTL, localizer.Translator("he").Direction())
+
+	infos := localizer.LocalesInfo()
+	assert.Equal(t, 3, len(infos))
+	assert.Equal(t, LocaleInfo{
+		Tag:         language.Hebrew,
+		Locale:      "he",
+		NativeName:  "עברית",
+		EnglishName: "Hebrew",
+		Script:      "Hebr",
+		Direction:   DirectionRTL,
+	}, infos[2])
+
+	handler := localizer.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "%s %s", r.Context().Value(MiddlewareContextLocaleKey), DirectionFromContext(r.Context()))
+	}))
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Add("Accept-Language", "ar-EG")
+	handler.ServeHTTP(response, request)
+	assert.Equal(t, "ar rtl", response.Body.String())
+	assert.Equal(t, Direction(""), DirectionFromContext(context.Background()))
+}
+
+func TestAvailableLanguages(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Path:    "./example/i18n",
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, []Language{
+		{Locale: "en", Name: "inglese", SelfName: "English", Direction: DirectionLTR},
+		{Locale: "it", Name: "italiano", SelfName: "italiano", Direction: DirectionLTR, Current: true},
+	}, localizer.AvailableLanguages("it-IT"))
+
+	response := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/languages", nil)
+	request.Header.Add("Accept-Language", "en")
+	localizer.LanguagesHandler().ServeHTTP(response, request)
+	assert.Equal(t, "en", response.Header().Get("Content-Language"))
+	assert.Equal(t, `[{"locale":"en","name":"English","selfName":"English","direction":"ltr","current":true},`+
+		`{"locale":"it","name":"Italian","selfName":"italiano","direction":"ltr","current":false}]`+"\n", response.Body.String())
+
+	var b bytes.Buffer
+	tmpl := template.Must(template.New("").Funcs(localizer.FuncMap("it")).Parse(
+		`{{ range Languages }}{{ .Locale }}{{ if .Current }}*{{ end }} {{ end }}`))
+	assert.Equal(t, nil, tmpl.Execute(&b, nil))
+	assert.Equal(t, "en it* ", b.String())
+}
+
+func TestTransport(t *testing.T) {
+	downstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, _ := r.Cookie("lang")
+		_, _ = fmt.Fprintf(w, "%s|%v|%s", r.Header.Get("Accept-Language"), cookie, r.URL.RawQuery)
+	}))
+	defer downstream.Close()
+
+	get := func(transport *Transport, ctx context.Context, url string) string {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		assert.Equal(t, nil, err)
+		resp, err := (&http.Client{Transport: transport}).Do(req)
+		assert.Equal(t, nil, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	ctx := context.WithValue(context.Background(), MiddlewareContextLocaleKey, "it")
+	assert.Equal(t, "||", get(&Transport{}, context.Background(), downstream.URL))
+	assert.Equal(t, "it||", get(&Transport{}, ctx, downstream.URL))
+	assert.Equal(t, "it|lang=it|a=1&lang=it", get(&Transport{Positions: DefaultHTTPLookUpStrategy}, ctx, downstream.URL+"?a=1"))
+	assert.Equal(t, "it|lang=it|lang=en", get(&Transport{Positions: DefaultHTTPLookUpStrategy}, ctx, downstream.URL+"?lang=en"))
+}
+
+func TestLocaleSources(t *testing.T) {
+	type userLocaleKey struct{}
+	flagLocale := ""
+
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String(), language.German.String()},
+		Locs:    map[string]map[string]string{},
+		LocaleSources: []LocaleSource{
+			ContextSource{Key: userLocaleKey{}},
+			HTTPLocalePosition{HTTPLocalePositionIDQuery, "lang"},
+			HTTPLocalePosition{HTTPLocalePositionIDPath, ""},
+			LocaleSourceFunc(func(*http.Request) []string { return []string{flagLocale} }),
+			HTTPLocalePosition{HTTPLocalePositionIDHost, ""},
+		},
+		MinConfidence: language.High,
+	})
+	assert.Equal(t, nil, err)
+
+	r := httptest.NewRequest(http.MethodGet, "http://de.example.com/it/products?lang=en", nil)
+	assert.Equal(t, "en", localizer.GetLocale(r))
+
+	r = httptest.NewRequest(http.MethodGet, "http://de.example.com/it/products", nil)
+	assert.Equal(t, "it", localizer.GetLocale(r))
+	assert.Equal(t, "de", localizer.GetLocale(httptest.NewRequest(http.MethodGet, "http://de.example.com/products", nil)))
+
+	r = r.WithContext(context.WithValue(r.Context(), userLocaleKey{}, []string{"ja", "de-AT"}))
+	assert.Equal(t, "de", localizer.GetLocale(r))
+
+	// without request
+	assert.Equal(t, "en", localizer.GetLocale(nil))
+	flagLocale = "it"
+	assert.Equal(t, "it", localizer.GetLocale(nil))
+}
+
+type testUser struct{ locale string }
+
+func (u testUser) Locale() string { return u.locale }
+
+func signJWT(secret string, claims map[string]interface{}) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload, _ := json.Marshal(claims)
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestJWTAndUserPositions(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String(), language.German.String()},
+		Locs:    map[string]map[string]string{},
+		HTTPLookUpStrategy: []HTTPLocalePosition{
+			{HTTPLocalePositionIDUser, "user"},
+			{HTTPLocalePositionIDJWT, "lang"},
+			{HTTPLocalePositionIDHeader, "Accept-Language"},
+		},
+		JWTSecret: "secret",
+	})
+	assert.Equal(t, nil, err)
+
+	request := func(token string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Language", "de")
+		if len(token) > 0 {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		return r
+	}
+
+	assert.Equal(t, "de", localizer.GetLocale(request("")))
+	assert.Equal(t, "it", localizer.GetLocale(request(signJWT("secret", map[string]interface{}{"lang": "it"}))))
+	assert.Equal(t, "de", localizer.GetLocale(request(signJWT("wrong", map[string]interface{}{"lang": "it"}))))
+	assert.Equal(t, "de", localizer.GetLocale(request(signJWT("secret", map[string]interface{}{
+		"lang": "it", "exp": time.Now().Add(-time.Minute).Unix(),
+	}))))
+	assert.Equal(t, "de", localizer.GetLocale(request("not.a.jwt")))
+
+	r := request(signJWT("secret", map[string]interface{}{"lang": "it"}))
+	r = r.WithContext(context.WithValue(r.Context(), "user", testUser{"en"}))
+	assert.Equal(t, "en", localizer.GetLocale(r))
+
+	// typed context key
+	type userKey struct{}
+	r = r.WithContext(context.WithValue(r.Context(), userKey{}, testUser{"de"}))
+	assert.Equal(t, []string{"de"}, UserSource{Key: userKey{}}.Locales(r))
+	assert.Equal(t, 0, len(UserSource{Key: userKey{}}.Locales(request(""))))
+
+	// custom verifier
+	localizer.Config.JWTVerifier = JWTVerifierFunc(func(alg string, signingInput, signature []byte) error {
+		return errors.New("rejected")
+	})
+	assert.Equal(t, "de", localizer.GetLocale(request(signJWT("secret", map[string]interface{}{"lang": "it"}))))
+
+	source := JWTSource{Verifier: HMACVerifier("secret")}
+	assert.Equal(t, []string{"it"}, source.Locales(request(signJWT("secret", map[string]interface{}{"locale": "it"}))))
+	assert.Equal(t, 0, len(JWTSource{}.Locales(request(signJWT("secret", map[string]interface{}{"locale": "it"})))))
+}
+
+func TestWriteError(t *testing.T) {
+	localizer, err := NewWithConfig(&Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Path:    "./example/i18n",
+	})
+	assert.Equal(t, nil, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/items/42", nil)
+	r.Header.Add("Accept-Language", "it-IT")
+
+	w := httptest.NewRecorder()
+	localizer.WriteError(w, r, http.StatusInternalServerError, GEM, "Marco")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "it", w.Header().Get("Content-Language"))
+	assert.Equal(t, `{"code":"GEM","message":"Qualcosa è andato storto, riprova più tardi Marco"}`+"\n", w.Body.String())
+
+	// the detail variant of the WriteProblem keys
+	w = httptest.NewRecorder()
+	localizer.WriteError(w, r, http.StatusNotFound, "ERR_NOT_FOUND", 42)
+	assert.Equal(t, `{"code":"ERR_NOT_FOUND","message":"L'elemento 42 non esiste"}`+"\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	localizer.WriteError(w, r, http.StatusBadRequest, "MISSING")
+	assert.Equal(t, `{"code":"MISSING","message":"MISSING"}`+"\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	localizer.WriteProblem(w, r, http.StatusNotFound, "ERR_NOT_FOUND", 42)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "application/problem+json; charset=utf-8", w.Header().Get("Content-Type"))
+	var problem Problem
+	assert.Equal(t, nil, json.NewDecoder(w.Body).Decode(&problem))
+	assert.Equal(t, Problem{
+		Title:    "Elemento non trovato",
+		Status:   http.StatusNotFound,
+		Detail:   "L'elemento 42 non esiste",
+		Instance: "/items/42",
+		Code:     "ERR_NOT_FOUND",
+	}, problem)
+
+	w = httptest.NewRecorder()
+	localizer.WriteProblem(w, r, http.StatusBadRequest, "OPEN")
+	assert.Equal(t, `{"title":"Richiesta non valida","status":400,"detail":"Apri","instance":"/items/42","code":"OPEN"}`+"\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	localizer.WriteProblem(w, nil, http.StatusBadRequest, "MISSING")
+	assert.Equal(t, `{"title":"Bad request","status":400,"code":"MISSING"}`+"\n", w.Body.String())
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
+
+	// no localized status text, the English one is used
+	w = httptest.NewRecorder()
+	localizer.WriteProblem(w, r, http.StatusConflict, "OPEN")
+	assert.Equal(t, `{"title":"Conflict","status":409,"detail":"Apri","instance":"/items/42","code":"OPEN"}`+"\n", w.Body.String())
+}
@@ -0,0 +1,26 @@
+module github.com/oblq/i18n/v2/i18ngrpc
+
+go 1.19
+
+require (
+	github.com/oblq/i18n/v2 v2.1.0
+	github.com/stretchr/testify v1.7.0
+	golang.org/x/text v0.14.0
+	google.golang.org/grpc v1.64.0
+)
+
+require (
+	github.com/BurntSushi/toml v0.4.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/oblq/swap v1.0.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.22.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+)
+
+// Develop against the core module in the parent directory,
+// the require above is the core version used by dependents.
+replace github.com/oblq/i18n/v2 => ../
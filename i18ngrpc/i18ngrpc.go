@@ -0,0 +1,130 @@
+// Package i18ngrpc propagates the request locale in gRPC calls.
+//
+// Server interceptors read the locale from the incoming metadata,
+// resolve it with i18n.I18n.MatchAvailableLanguageTag and set it, and
+// its direction, in the context as i18n.I18n.Middleware does for http requests:
+//
+//	server := grpc.NewServer(
+//		grpc.UnaryInterceptor(i18ngrpc.UnaryServerInterceptor(localizer, nil)),
+//		grpc.StreamInterceptor(i18ngrpc.StreamServerInterceptor(localizer, nil)),
+//	)
+//
+//	func (s *server) SayHello(ctx context.Context, in *pb.HelloRequest) (*pb.HelloReply, error) {
+//		locale := i18ngrpc.Locale(ctx)
+//		dir := i18n.DirectionFromContext(ctx)
+//		...
+//	}
+//
+// Client interceptors forward the locale in context to the outgoing metadata.
+package i18ngrpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/oblq/i18n/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// DefaultMetadataKey is the default locale metadata key.
+const DefaultMetadataKey = "accept-language"
+
+// Config is the i18ngrpc config struct.
+type Config struct {
+	// MetadataKeys are the metadata keys the locale is looked up in,
+	// in order, the first one is used by the client interceptors.
+	// Default is DefaultMetadataKey.
+	MetadataKeys []string
+}
+
+func (c *Config) metadataKeys() []string {
+	if c == nil || len(c.MetadataKeys) == 0 {
+		return []string{DefaultMetadataKey}
+	}
+	return c.MetadataKeys
+}
+
+// NewContext return a copy of ctx with the given locale,
+// to be forwarded by the client interceptors.
+func NewContext(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, i18n.MiddlewareContextLocaleKey, locale)
+}
+
+// Locale return the locale in ctx, if any.
+func Locale(ctx context.Context) string {
+	locale, _ := ctx.Value(i18n.MiddlewareContextLocaleKey).(string)
+	return locale
+}
+
+// localeContext resolve the incoming metadata locale into ctx.
+func localeContext(ctx context.Context, localizer *i18n.I18n, config *Config) context.Context {
+	var locale string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, key := range config.metadataKeys() {
+			if values := md.Get(key); len(values) > 0 {
+				locale = strings.Join(values, ",")
+				break
+			}
+		}
+	}
+	tag := localizer.MatchAvailableLanguageTag(locale)
+	ctx = NewContext(ctx, tag.String())
+	return i18n.NewDirectionContext(ctx, localizer.Direction(tag))
+}
+
+// UnaryServerInterceptor set the incoming metadata locale in context,
+// config can be nil.
+func UnaryServerInterceptor(localizer *i18n.I18n, config *Config) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(localeContext(ctx, localizer, config), req)
+	}
+}
+
+// StreamServerInterceptor set the incoming metadata locale in the stream context,
+// config can be nil.
+func StreamServerInterceptor(localizer *i18n.I18n, config *Config) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: localeContext(ss.Context(), localizer, config)})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// outgoingContext forward the locale in ctx to the outgoing metadata,
+// unless it is already set.
+func outgoingContext(ctx context.Context, config *Config) context.Context {
+	locale := Locale(ctx)
+	if len(locale) == 0 {
+		return ctx
+	}
+
+	key := config.metadataKeys()[0]
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(key)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, key, locale)
+}
+
+// UnaryClientInterceptor forward the locale in context
+// to the outgoing metadata, config can be nil.
+func UnaryClientInterceptor(config *Config) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx, config), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor forward the locale in context
+// to the outgoing metadata, config can be nil.
+func StreamClientInterceptor(config *Config) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx, config), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,86 @@
+package i18ngrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/oblq/i18n/v2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// healthServer record the request locale and direction.
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	locale    string
+	direction i18n.Direction
+}
+
+func (s *healthServer) Check(ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	s.locale, s.direction = Locale(ctx), i18n.DirectionFromContext(ctx)
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (s *healthServer) Watch(_ *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	s.locale = Locale(stream.Context())
+	return stream.Send(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING})
+}
+
+func TestInterceptors(t *testing.T) {
+	localizer, err := i18n.NewWithConfig(&i18n.Config{
+		Locales: []string{language.English.String(), language.Italian.String(), language.Arabic.String()},
+		Locs:    map[string]map[string]string{},
+	})
+	assert.Equal(t, nil, err)
+
+	config := &Config{MetadataKeys: []string{"x-locale", DefaultMetadataKey}}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(localizer, config)),
+		grpc.StreamInterceptor(StreamServerInterceptor(localizer, config)),
+	)
+	health := &healthServer{}
+	grpc_health_v1.RegisterHealthServer(server, health)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor(config)),
+		grpc.WithStreamInterceptor(StreamClientInterceptor(config)),
+	)
+	assert.Equal(t, nil, err)
+	defer conn.Close()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	// default locale
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "en", health.locale)
+
+	// forwarded by the client interceptor
+	_, err = client.Check(NewContext(context.Background(), "ar-EG"), &grpc_health_v1.HealthCheckRequest{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "ar", health.locale)
+	assert.Equal(t, i18n.DirectionRTL, health.direction)
+
+	// explicit metadata, the fallback key
+	ctx := metadata.AppendToOutgoingContext(context.Background(), DefaultMetadataKey, "it-IT, en;q=0.5")
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "it", health.locale)
+
+	stream, err := client.Watch(NewContext(context.Background(), "it"), &grpc_health_v1.HealthCheckRequest{})
+	assert.Equal(t, nil, err)
+	_, err = stream.Recv()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "it", health.locale)
+}
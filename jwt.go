@@ -0,0 +1,188 @@
+package i18n
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultJWTClaim is the default locale claim
+// read by HTTPLocalePositionIDJWT positions.
+const DefaultJWTClaim = "locale"
+
+// ErrInvalidJWT is returned for malformed or expired tokens.
+var ErrInvalidJWT = errors.New("invalid jwt")
+
+// JWTVerifier verify the signature of the bearer tokens
+// read by JWTSource, e.g.: HMACVerifier.
+type JWTVerifier interface {
+	// Verify return an error if signature is not valid for
+	// signingInput (`<header>.<payload>`) using the alg algorithm.
+	Verify(alg string, signingInput, signature []byte) error
+}
+
+// JWTVerifierFunc is a func JWTVerifier.
+type JWTVerifierFunc func(alg string, signingInput, signature []byte) error
+
+// Verify implements JWTVerifier.
+func (f JWTVerifierFunc) Verify(alg string, signingInput, signature []byte) error {
+	return f(alg, signingInput, signature)
+}
+
+// HMACVerifier is a JWTVerifier for the HS256, HS384
+// and HS512 algorithms, HMACVerifier is the secret.
+type HMACVerifier []byte
+
+// Verify implements JWTVerifier.
+func (secret HMACVerifier) Verify(alg string, signingInput, signature []byte) error {
+	var h func() hash.Hash
+	switch alg {
+	case "HS256":
+		h = sha256.New
+	case "HS384":
+		h = sha512.New384
+	case "HS512":
+		h = sha512.New
+	default:
+		return fmt.Errorf("%w: unsupported algorithm '%s'", ErrInvalidJWT, alg)
+	}
+
+	mac := hmac.New(h, secret)
+	mac.Write(signingInput)
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidJWT)
+	}
+	return nil
+}
+
+// JWTSource is a LocaleSource reading the locale Claim
+// of the `Authorization: Bearer <token>` header JWT,
+// tokens are ignored if Verifier is nil or can't verify them.
+// HTTPLocalePositionIDJWT positions are JWTSource using
+// the position Key as Claim and i18n.Config.JWTVerifier
+// or i18n.Config.JWTSecret.
+type JWTSource struct {
+	// Claim is the locale claim name.
+	// Default is DefaultJWTClaim.
+	Claim    string
+	Verifier JWTVerifier
+}
+
+// Locales implements LocaleSource.
+func (s JWTSource) Locales(r *http.Request) []string {
+	if r == nil || s.Verifier == nil {
+		return nil
+	}
+
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) < 7 || !strings.EqualFold(authorization[:7], "Bearer ") {
+		return nil
+	}
+
+	claims, err := parseJWT(strings.TrimSpace(authorization[7:]), s.Verifier)
+	if err != nil {
+		return nil
+	}
+
+	claim := s.Claim
+	if len(claim) == 0 {
+		claim = DefaultJWTClaim
+	}
+	if locale, ok := claims[claim].(string); ok && len(locale) > 0 {
+		return []string{locale}
+	}
+	return nil
+}
+
+// parseJWT verify the token and return its claims,
+// expired tokens (`exp` claim) are invalid.
+func parseJWT(token string, verifier JWTVerifier) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidJWT
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidJWT, err.Error())
+	}
+	if err = verifier.Verify(header.Alg, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	if err = decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if exp, ok := claims["exp"].(float64); ok && time.Now().Unix() >= int64(exp) {
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidJWT)
+	}
+	return claims, nil
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidJWT, err.Error())
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidJWT, err.Error())
+	}
+	return nil
+}
+
+// LocaleUser is implemented by user objects holding
+// the user preferred locale, see UserSource.
+type LocaleUser interface {
+	Locale() string
+}
+
+// UserSource is a LocaleSource reading the locale of the
+// LocaleUser found in the request context value with the given Key,
+// e.g.: set by an authentication middleware.
+// Prefer an unexported key type, as for context.WithValue,
+// the HTTPLocalePositionIDUser position uses its string Key
+// that may collide with other context values.
+type UserSource struct {
+	Key interface{}
+}
+
+// Locales implements LocaleSource.
+func (s UserSource) Locales(r *http.Request) []string {
+	if r == nil {
+		return nil
+	}
+	if user, ok := r.Context().Value(s.Key).(LocaleUser); ok {
+		if locale := user.Locale(); len(locale) > 0 {
+			return []string{locale}
+		}
+	}
+	return nil
+}
+
+// jwtVerifier return i18n.Config.JWTVerifier, or an HMACVerifier
+// with i18n.Config.JWTSecret, nil if none is set.
+func (i18n *I18n) jwtVerifier() JWTVerifier {
+	if i18n.Config.JWTVerifier != nil {
+		return i18n.Config.JWTVerifier
+	}
+	if len(i18n.Config.JWTSecret) > 0 {
+		return HMACVerifier(i18n.Config.JWTSecret)
+	}
+	return nil
+}
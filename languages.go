@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// Language is an available language as shown in a language picker.
+type Language struct {
+	Locale string `json:"locale"`
+	// Name is the language name in the display language, e.g.: `Italian`.
+	Name string `json:"name"`
+	// SelfName is the language name in the language itself, e.g.: `italiano`.
+	SelfName  string    `json:"selfName"`
+	Direction Direction `json:"direction"`
+	// Current is true for the display language.
+	Current bool `json:"current"`
+}
+
+// AvailableLanguages return the i18n.Tags languages,
+// in the same order, named in the display language.
+// The language matching displayLocale is marked as Current.
+func (i18n *I18n) AvailableLanguages(displayLocale string) []Language {
+	displayTag := i18n.MatchAvailableLanguageTag(displayLocale)
+
+	namer := display.Tags(displayTag)
+	if namer == nil {
+		namer = display.English.Tags()
+	}
+
+	languages := make([]Language, len(i18n.Tags))
+	for i, tag := range i18n.Tags {
+		languages[i] = Language{
+			Locale:    tag.String(),
+			Name:      displayName(namer, tag),
+			SelfName:  displayName(display.Self, tag),
+			Direction: direction(tag),
+			Current:   tag == displayTag,
+		}
+	}
+	return languages
+}
+
+// displayName return the tag name, or the locale
+// if namer has no name for it.
+func displayName(namer display.Namer, tag language.Tag) string {
+	if name := namer.Name(tag); len(name) > 0 {
+		return name
+	}
+	return tag.String()
+}
+
+// LanguagesHandler serve the AvailableLanguages as JSON,
+// named in the request language, for frontend language pickers:
+//
+//	mux.Handle("/languages", localizer.LanguagesHandler())
+//	// -> [{"locale":"en","name":"English","selfName":"English","direction":"ltr","current":true}, ...]
+func (i18n *I18n) LanguagesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := i18n.GetLocale(r)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Content-Language", locale)
+		if err := json.NewEncoder(w).Encode(i18n.AvailableLanguages(locale)); err != nil {
+			i18n.logf("can't encode languages: %s", err.Error())
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package i18n
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// Built-in layer names, see Layer.
+const (
+	// LayerLocs is the layer of Config.Locs.
+	LayerLocs = "locs"
+	// LayerPath is the layer of the localization files in Config.Path.
+	LayerPath = "path"
+)
+
+// Layer is a source of localizations.
+// Layers are merged in the declared order,
+// a key found in a layer overrides the same key
+// found in the previous ones (per locale).
+//
+// Config.Locs and Config.Path are always the first two layers,
+// named LayerLocs and LayerPath, so that hardcoded defaults
+// embedded in a library can be overridden by localization
+// files and then by any Config.Layers, e.g.:
+//
+//	Layers: []i18n.Layer{{Name: "overrides", Path: "./overrides"}}
+type Layer struct {
+	// Name identify the layer, see I18n.Origin.
+	Name string
+
+	// Store is the layer localizations Store,
+	// it takes precedence over Path and Locs.
+	Store Store `json:"-" yaml:"-" toml:"-"`
+
+	// Path is the path of the layer localization files,
+	// it takes precedence over Locs.
+	Path string
+
+	// Locs contains the layer hardcoded localizations.
+	Locs map[string]map[string]string
+}
+
+// layer is a loaded Layer.
+type layer struct {
+	name  string
+	store Store
+}
+
+// layers is a Store looking up keys in every layer,
+// from the highest precedence to the lowest.
+type layers []layer
+
+// Lookup implements Store.
+func (ls *layers) Lookup(locale string, key string) (string, bool) {
+	if l, ok := ls.origin(locale, key); ok {
+		return l.store.Lookup(locale, key)
+	}
+	return "", false
+}
+
+// Keys implements Store.
+func (ls *layers) Keys(locale string) []string {
+	unique := make(map[string]bool)
+	for _, l := range *ls {
+		for _, key := range l.store.Keys(locale) {
+			unique[key] = true
+		}
+	}
+
+	keys := make([]string, 0, len(unique))
+	for key := range unique {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Watch implements Store.
+func (ls *layers) Watch(onChange func()) {
+	for _, l := range *ls {
+		l.store.Watch(onChange)
+	}
+}
+
+// origin return the layer which supply key for locale.
+func (ls *layers) origin(locale string, key string) (layer, bool) {
+	for _, l := range *ls {
+		if _, ok := l.store.Lookup(locale, key); ok {
+			return l, true
+		}
+	}
+	return layer{}, false
+}
+
+// loadLayers load all the configured layers into i18n.layers.
+// Layers without localizations are skipped, all the layers are
+// loaded anyway, files failures of every layer are returned
+// together as LocalizationFilesError.
+func (i18n *I18n) loadLayers() error {
+	configured := append([]Layer{
+		{Name: LayerLocs, Locs: i18n.Config.Locs},
+		{Name: LayerPath, Path: i18n.Config.Path},
+	}, i18n.Config.Layers...)
+
+	i18n.layers = nil
+	var filesErr LocalizationFilesError
+	for _, l := range configured {
+		var store Store
+		switch {
+		case l.Store != nil:
+			store = l.Store
+		case len(l.Path) > 0:
+			localizations, err := loadLocalizationFiles(i18n.Tags, func(locale string) string {
+				return filepath.Join(l.Path, locale)
+			})
+			if layerErr, ok := err.(LocalizationFilesError); ok {
+				filesErr = append(filesErr, layerErr...)
+			} else if err != nil {
+				return err
+			}
+			store = localizations
+		case l.Locs != nil:
+			store = MapStore(l.Locs)
+		default:
+			continue
+		}
+
+		i18n.layers = append(layers{{name: l.Name, store: store}}, i18n.layers...)
+	}
+
+	if len(filesErr) > 0 {
+		return filesErr
+	}
+	return nil
+}
+
+// Origin return the name of the layer which supplied
+// the localization of key for the given locale,
+// ok is false if the key is not localized in that locale.
+func (i18n *I18n) Origin(locale string, key string) (layer string, ok bool) {
+	l, ok := i18n.layers.origin(locale, key)
+	return l.name, ok
+}
+
+// Store return the Store of the merged layers,
+// the one used to translate keys.
+func (i18n *I18n) Store() Store {
+	return &i18n.layers
+}
@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// ListStyle is the kind of list to format.
+type ListStyle int
+
+const (
+	ListStyleAnd  ListStyle = iota // conjunction, e.g.: `a, b, and c`
+	ListStyleOr                    // disjunction, e.g.: `a, b, or c`
+	ListStyleUnit                  // list of quantities, e.g.: `2 hours, 3 minutes`
+)
+
+// index return the patterns index of style,
+// unknown styles fall back to ListStyleAnd.
+func (style ListStyle) index() ListStyle {
+	if style < ListStyleAnd || style > ListStyleUnit {
+		return ListStyleAnd
+	}
+	return style
+}
+
+// listPattern is a CLDR list pattern.
+type listPattern struct {
+	start, middle, end, two string
+}
+
+// listPatterns holds the CLDR list patterns by base language
+// and ListStyle, only en, it, de, fr, es and pt are supported,
+// unlisted languages use English.
+var listPatterns = map[string][3]listPattern{
+	"en": {
+		{"{0}, {1}", "{0}, {1}", "{0}, and {1}", "{0} and {1}"},
+		{"{0}, {1}", "{0}, {1}", "{0}, or {1}", "{0} or {1}"},
+		{"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, {1}"},
+	},
+	"it": {
+		{"{0}, {1}", "{0}, {1}", "{0} e {1}", "{0} e {1}"},
+		{"{0}, {1}", "{0}, {1}", "{0} o {1}", "{0} o {1}"},
+		{"{0}, {1}", "{0}, {1}", "{0} e {1}", "{0} e {1}"},
+	},
+	"de": {
+		{"{0}, {1}", "{0}, {1}", "{0} und {1}", "{0} und {1}"},
+		{"{0}, {1}", "{0}, {1}", "{0} oder {1}", "{0} oder {1}"},
+		{"{0}, {1}", "{0}, {1}", "{0} und {1}", "{0}, {1}"},
+	},
+	"fr": {
+		{"{0}, {1}", "{0}, {1}", "{0} et {1}", "{0} et {1}"},
+		{"{0}, {1}", "{0}, {1}", "{0} ou {1}", "{0} ou {1}"},
+		{"{0}, {1}", "{0}, {1}", "{0} et {1}", "{0} et {1}"},
+	},
+	"es": {
+		{"{0}, {1}", "{0}, {1}", "{0} y {1}", "{0} y {1}"},
+		{"{0}, {1}", "{0}, {1}", "{0} o {1}", "{0} o {1}"},
+		{"{0}, {1}", "{0}, {1}", "{0} y {1}", "{0} y {1}"},
+	},
+	"pt": {
+		{"{0}, {1}", "{0}, {1}", "{0} e {1}", "{0} e {1}"},
+		{"{0}, {1}", "{0}, {1}", "{0} ou {1}", "{0} ou {1}"},
+		{"{0}, {1}", "{0}, {1}", "{0} e {1}", "{0} e {1}"},
+	},
+}
+
+// FormatList join items using the list pattern
+// of the given style and locale,
+// e.g.: `a, b, and c` (en) or `a, b e c` (it) for ListStyleAnd.
+// Lists are localized in en, it, de, fr, es and pt,
+// other languages use the English patterns.
+// Unknown styles fall back to ListStyleAnd.
+func (i18n *I18n) FormatList(locale string, items []string, style ListStyle) string {
+	return formatList(i18n.MatchAvailableLanguageTag(locale), items, style)
+}
+
+func formatList(tag language.Tag, items []string, style ListStyle) string {
+	base, _ := tag.Base()
+	patterns, ok := listPatterns[base.String()]
+	if !ok {
+		patterns = listPatterns["en"]
+	}
+	pattern := patterns[style.index()]
+
+	join := func(pattern, first, second string) string {
+		return strings.NewReplacer("{0}", first, "{1}", second).Replace(pattern)
+	}
+
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return join(pattern.two, items[0], items[1])
+	}
+
+	result := join(pattern.end, items[len(items)-2], items[len(items)-1])
+	for i := len(items) - 3; i > 0; i-- {
+		result = join(pattern.middle, items[i], result)
+	}
+	return join(pattern.start, items[0], result)
+}
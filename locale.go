@@ -1,46 +1,132 @@
 package i18n
 
 import (
-	"log"
 	"net/http"
+	"strings"
 
 	"golang.org/x/text/language"
 )
 
 // getLocaleUnsafe return the request locale.
-// It first looks for the locale by the
-// GetLocaleOverride func (if defined),
-// then if locale is empty it will look in:
-// - cookies ("language" and/or "lang" keys)
-// - 'Accept-Language' header
+// The candidates are collected, in order, from the
+// GetLocaleOverride func (if defined), then from
+// the i18n.Config.LocaleSources, or from the
+// i18n.Config.HTTPLookUpStrategy positions.
+// Every preference of Accept-Language values
+// is a candidate, in quality order.
+// The first candidate matching one of the available
+// locales with at least language.Low confidence,
+// or i18n.Config.MinConfidence if higher, is returned.
+// r can be nil, only the sources not depending
+// on the request are evaluated then.
 func (i18n *I18n) getLocaleUnsafe(r *http.Request) (locale string) {
-	if r == nil {
-		return
+	var candidates []string
+	if i18n.GetLocaleOverride != nil && r != nil {
+		candidates = append(candidates, i18n.GetLocaleOverride(r))
+	}
+	for _, source := range i18n.localeSources() {
+		candidates = append(candidates, source.Locales(r)...)
 	}
 
-	if i18n.GetLocaleOverride != nil {
-		locale = i18n.GetLocaleOverride(r)
+	minConfidence := i18n.Config.MinConfidence
+	if minConfidence < language.Low {
+		minConfidence = language.Low
 	}
 
-	if len(locale) == 0 {
-		for _, lookUpStrategy := range i18n.Config.HTTPLookUpStrategy {
-			switch lookUpStrategy.ID {
-			case HTTPLocalePositionIDHeader:
-				locale = r.Header.Get(lookUpStrategy.Key)
-			case HTTPLocalePositionIDCookie:
-				if cookieLang, err := r.Cookie(lookUpStrategy.Key); err == nil {
-					locale = cookieLang.Value
-				}
-			case HTTPLocalePositionIDQuery:
-				locale = r.URL.Query().Get(lookUpStrategy.Key)
+	for _, candidate := range candidates {
+		if tag, ok := i18n.matchPseudoLocale(candidate); ok {
+			return tag.String()
+		}
+
+		// We ignore the error: the tags parsed before it are returned anyway.
+		tags, _, _ := language.ParseAcceptLanguage(candidate)
+		for _, tag := range tags {
+			if _, _, confidence := i18n.matcher.Match(tag); confidence >= minConfidence {
+				return tag.String()
 			}
+		}
+	}
 
-			if len(locale) > 0 {
-				break
+	return ""
+}
+
+// localeSources return i18n.Config.LocaleSources or,
+// if not set, the i18n.Config.HTTPLookUpStrategy positions,
+// with the jwt and user positions replaced by their sources.
+func (i18n *I18n) localeSources() []LocaleSource {
+	configured := i18n.Config.LocaleSources
+	if len(configured) == 0 {
+		configured = make([]LocaleSource, len(i18n.Config.HTTPLookUpStrategy))
+		for i, position := range i18n.Config.HTTPLookUpStrategy {
+			configured[i] = position
+		}
+	}
+
+	sources := make([]LocaleSource, len(configured))
+	for i, source := range configured {
+		sources[i] = source
+		if position, ok := source.(HTTPLocalePosition); ok {
+			switch position.ID {
+			case HTTPLocalePositionIDJWT:
+				sources[i] = JWTSource{Claim: position.Key, Verifier: i18n.jwtVerifier()}
+			case HTTPLocalePositionIDUser:
+				sources[i] = UserSource{Key: position.Key}
 			}
 		}
 	}
-	return
+	return sources
+}
+
+// locale return the raw locale found in the request
+// at the given position, or an empty string.
+func (p HTTPLocalePosition) locale(r *http.Request) string {
+	switch p.ID {
+	case HTTPLocalePositionIDHeader:
+		return r.Header.Get(p.Key)
+	case HTTPLocalePositionIDCookie:
+		if cookieLang, err := r.Cookie(p.Key); err == nil {
+			return cookieLang.Value
+		}
+	case HTTPLocalePositionIDQuery:
+		return r.URL.Query().Get(p.Key)
+	case HTTPLocalePositionIDPath:
+		segment := strings.TrimPrefix(r.URL.Path, "/")
+		if i := strings.IndexByte(segment, '/'); i >= 0 {
+			segment = segment[:i]
+		}
+		return segment
+	case HTTPLocalePositionIDHost:
+		host := r.Host
+		if i := strings.IndexByte(host, '.'); i >= 0 {
+			return host[:i]
+		}
+	}
+	return ""
+}
+
+// Locales implements LocaleSource.
+func (p HTTPLocalePosition) Locales(r *http.Request) []string {
+	if r == nil {
+		return nil
+	}
+	if locale := p.locale(r); len(locale) > 0 {
+		return []string{locale}
+	}
+	return nil
+}
+
+// setLocale set locale in the position of r.
+func (p HTTPLocalePosition) setLocale(r *http.Request, locale string) {
+	switch p.ID {
+	case HTTPLocalePositionIDHeader:
+		r.Header.Set(p.Key, locale)
+	case HTTPLocalePositionIDCookie:
+		r.AddCookie(&http.Cookie{Name: p.Key, Value: locale})
+	case HTTPLocalePositionIDQuery:
+		query := r.URL.Query()
+		query.Set(p.Key, locale)
+		r.URL.RawQuery = query.Encode()
+	}
 }
 
 // GetLanguageTag return the request language.Tag.
@@ -65,31 +151,48 @@ func (i18n *I18n) GetLocale(r *http.Request) (locale string) {
 // corresponding language.Tag.
 //  <language.Tag>.String() // -> locale
 // A recognized language is always returned.
-// If no locale is matched the first one from the supported list
-// will be returned.
+// If no locale is matched, or if it is matched with a confidence
+// lower than i18n.Config.MinConfidence, the first one from
+// the supported list will be returned.
 func (i18n *I18n) MatchAvailableLanguageTag(locale string) language.Tag {
-	if len(locale) > 0 {
-		// We ignore the error: the default language will be selected for t == nil.
-		t, _, _ := language.ParseAcceptLanguage(locale)
-		// we don't return tag anymore since it has some bugs, we can retrieve it from supported languages with index
-		//tag, _, _ := matcher.Match(t...)
-		_, i, _ := i18n.matcher.Match(t...)
-
-		if len(i18n.Tags) > i {
-			return i18n.Tags[i]
-		}
+	if tag, ok := i18n.matchLanguageTag(locale); ok {
+		return tag
 	}
 	return i18n.Tags[0]
 }
 
+// matchLanguageTag return the available language.Tag matching locale,
+// ok is false if locale is empty or if it is matched with a confidence
+// lower than i18n.Config.MinConfidence.
+func (i18n *I18n) matchLanguageTag(locale string) (tag language.Tag, ok bool) {
+	if len(locale) == 0 {
+		return
+	}
+
+	if tag, ok = i18n.matchPseudoLocale(locale); ok {
+		return
+	}
+
+	// We ignore the error: the default language will be selected for t == nil.
+	t, _, _ := language.ParseAcceptLanguage(locale)
+	// we don't return tag anymore since it has some bugs, we can retrieve it from supported languages with index
+	//tag, _, _ := matcher.Match(t...)
+	_, i, confidence := i18n.matcher.Match(t...)
+
+	if confidence < i18n.Config.MinConfidence || len(i18n.Tags) <= i {
+		return
+	}
+	return i18n.Tags[i], true
+}
+
 // parseLocalesToTags convert an array of locales to an array of language.Tag.
 // If no language.Tag can be parsed for the provided locales
 // then language.English will be returned by default in tags array.
-func parseLocalesToTags(locales []string) (tags []language.Tag) {
+func parseLocalesToTags(locales []string) (tags []language.Tag, err error) {
 	for _, locale := range locales {
 		newTag, err := language.Parse(locale)
 		if err != nil {
-			log.Fatal("can't parse one or more locale identifier:", err.Error())
+			return nil, &LocaleParseError{Locale: locale, Err: err}
 		}
 
 		tags = append(tags, newTag)
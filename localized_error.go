@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Error is an error carrying a localization key and its params,
+// it is translated lazily, usually far from where it is created,
+// by LocalizeError or AutoLocalizeError.
+// Error() translate the key using the default locale.
+type Error struct {
+	Key    string
+	Params []interface{}
+
+	// Err is the optional underlying error.
+	Err error
+
+	i18n *I18n
+}
+
+// NewError return a new localized error for the given key and params.
+func (i18n *I18n) NewError(key string, params ...interface{}) *Error {
+	return &Error{Key: key, Params: params, i18n: i18n}
+}
+
+// Error translate the key using the default locale,
+// the key is returned if the Error is not bound
+// to an i18n instance (see NewError).
+func (e *Error) Error() string {
+	if e.i18n == nil {
+		return e.Key
+	}
+	return e.i18n.T(e.i18n.Tags[0].String(), e.Key, e.Params...)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// LocalizeError translate err based on the passed locale.
+// The err chain is walked using errors.As and the first *Error
+// found is translated, otherwise err.Error() is returned.
+func (i18n *I18n) LocalizeError(locale string, err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var localizedErr *Error
+	if errors.As(err, &localizedErr) {
+		return i18n.translate(locale, localizedErr.Key, localizedErr.Params...)
+	}
+	return err.Error()
+}
+
+// AutoLocalizeError automatically translate err based on the http request,
+// see LocalizeError and AutoT.
+func (i18n *I18n) AutoLocalizeError(r *http.Request, err error) string {
+	return i18n.LocalizeError(i18n.GetLocale(r), err)
+}
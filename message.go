@@ -0,0 +1,73 @@
+package i18n
+
+// Message is a localization key with its params,
+// it can be defined before the locale is known
+// (e.g.: at init time) and translated later by Render.
+// Messages passed as params to T, AutoT, Render, etc...
+// are translated in the same locale.
+type Message struct {
+	Key    string
+	Params []interface{}
+
+	i18n *I18n
+}
+
+// NewMessage return a new Message for the given key and params.
+func (i18n *I18n) NewMessage(key string, params ...interface{}) Message {
+	return Message{Key: key, Params: params, i18n: i18n}
+}
+
+// String translate the message using the default locale,
+// the key is returned if the Message is not bound
+// to an i18n instance (see NewMessage).
+func (m Message) String() string {
+	if m.i18n == nil {
+		return m.Key
+	}
+	return m.i18n.Render(m.i18n.Tags[0].String(), m)
+}
+
+// Render translate msg based on the passed locale.
+func (i18n *I18n) Render(locale string, msg Message) string {
+	return i18n.translate(locale, msg.Key, msg.Params...)
+}
+
+// FuncMap return the template functions bound to locale,
+// it can be passed to both text/template and html/template Funcs:
+//
+//	{{ T "MY_KEY" "param" }}
+//	{{ Render .Title }}
+//	{{ range Languages }}<option value="{{ .Locale }}" {{ if .Current }}selected{{ end }}>{{ .SelfName }}</option>{{ end }}
+func (i18n *I18n) FuncMap(locale string) map[string]interface{} {
+	return map[string]interface{}{
+		"T": func(key string, params ...interface{}) string {
+			return i18n.translate(locale, key, params...)
+		},
+		"Render": func(msg Message) string {
+			return i18n.Render(locale, msg)
+		},
+		"Languages": func() []Language {
+			return i18n.AvailableLanguages(locale)
+		},
+	}
+}
+
+// localizeParams translate the Message params in locale,
+// params is returned untouched if there are none.
+func (i18n *I18n) localizeParams(locale string, params []interface{}) []interface{} {
+	var localized []interface{}
+	for i, param := range params {
+		if msg, ok := param.(Message); ok {
+			if localized == nil {
+				localized = make([]interface{}, len(params))
+				copy(localized, params)
+			}
+			localized[i] = i18n.Render(locale, msg)
+		}
+	}
+
+	if localized == nil {
+		return params
+	}
+	return localized
+}
@@ -7,13 +7,30 @@ import (
 
 const MiddlewareContextLocaleKey = "locale"
 
+// directionContextKey is the context key of the request
+// locale Direction, unexported to avoid collisions.
+type directionContextKey struct{}
+
+// NewDirectionContext return a copy of ctx with the given Direction.
+func NewDirectionContext(ctx context.Context, dir Direction) context.Context {
+	return context.WithValue(ctx, directionContextKey{}, dir)
+}
+
+// DirectionFromContext return the request locale Direction
+// set by Middleware, empty if ctx has none.
+func DirectionFromContext(ctx context.Context) Direction {
+	dir, _ := ctx.Value(directionContextKey{}).(Direction)
+	return dir
+}
+
 // Middleware looks for a language setting in the request
-// and sets the request locale in context.
+// and sets the request locale, and its Direction, in context.
 // It looks for the language using the `i18n.Config.HTTPLookUpStrategy`.
 func (i18n *I18n) Middleware(nextHandler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		locale := i18n.GetLocale(r)
-		updatedContext := context.WithValue(r.Context(), MiddlewareContextLocaleKey, locale)
+		tag := i18n.GetLanguageTag(r)
+		updatedContext := context.WithValue(r.Context(), MiddlewareContextLocaleKey, tag.String())
+		updatedContext = NewDirectionContext(updatedContext, direction(tag))
 		updatedRequest := r.WithContext(updatedContext)
 		nextHandler.ServeHTTP(w, updatedRequest)
 	})
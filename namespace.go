@@ -0,0 +1,211 @@
+package i18n
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// NamespacesLayout is the layout of the namespaces localization files.
+type NamespacesLayout string
+
+const (
+	// NamespacesLayoutNamespaceDir is `<Path>/<namespace>/<locale>.yaml`.
+	NamespacesLayoutNamespaceDir NamespacesLayout = "namespace/locale"
+	// NamespacesLayoutLocaleDir is `<Path>/<locale>/<namespace>.yaml`.
+	NamespacesLayoutLocaleDir NamespacesLayout = "locale/namespace"
+)
+
+// namespaceSeparator separates the namespace
+// from the key, e.g.: `checkout:PAY_NOW`.
+const namespaceSeparator = ":"
+
+// Namespace is a bundle of localizations loaded from
+// its own localization files, so that different teams or
+// components can keep their keys apart.
+// Keys of a namespace can also be translated
+// by the I18n instance as `<namespace>:<key>`:
+//
+//	localizer.T("en", "checkout:PAY_NOW")
+//	localizer.Namespace("checkout").T("en", "PAY_NOW")
+//
+// Namespaces are loaded on first use from i18n.Config.Path,
+// following i18n.Config.NamespacesLayout.
+// Keys are split on the first `:` only if the prefix is a namespace
+// found in i18n.Config.Path or declared using I18n.Namespace
+// or I18n.LoadNamespace, so that keys like `Error: %s` are
+// translated from the main localizations.
+type Namespace struct {
+	Name string
+
+	i18n *I18n
+}
+
+// Namespace return the namespace with the given name,
+// declaring it if it was not found in i18n.Config.Path.
+func (i18n *I18n) Namespace(name string) *Namespace {
+	i18n.namespacesMutex.Lock()
+	if i18n.knownNamespaces == nil {
+		i18n.knownNamespaces = make(map[string]bool)
+	}
+	i18n.knownNamespaces[name] = true
+	i18n.namespacesMutex.Unlock()
+
+	return &Namespace{Name: name, i18n: i18n}
+}
+
+func (ns *Namespace) key(key string) string {
+	return ns.Name + namespaceSeparator + key
+}
+
+// T see I18n.T.
+func (ns *Namespace) T(locale string, key string, params ...interface{}) string {
+	return ns.i18n.T(locale, ns.key(key), params...)
+}
+
+// TP see I18n.TP.
+func (ns *Namespace) TP(locale string, key string, params ...interface{}) string {
+	return ns.i18n.TP(locale, ns.key(key), params...)
+}
+
+// TS see I18n.TS.
+func (ns *Namespace) TS(locale string, key string, selector string, params ...interface{}) string {
+	return ns.i18n.TS(locale, ns.key(key), selector, params...)
+}
+
+// TC see I18n.TC.
+func (ns *Namespace) TC(locale string, context string, key string, params ...interface{}) string {
+	return ns.i18n.TC(locale, context, ns.key(key), params...)
+}
+
+// AutoT see I18n.AutoT.
+func (ns *Namespace) AutoT(r *http.Request, key string, params ...interface{}) string {
+	return ns.i18n.AutoT(r, ns.key(key), params...)
+}
+
+// AutoTP see I18n.AutoTP.
+func (ns *Namespace) AutoTP(r *http.Request, key string, params ...interface{}) string {
+	return ns.i18n.AutoTP(r, ns.key(key), params...)
+}
+
+// AutoTS see I18n.AutoTS.
+func (ns *Namespace) AutoTS(r *http.Request, key string, selector string, params ...interface{}) string {
+	return ns.i18n.AutoTS(r, ns.key(key), selector, params...)
+}
+
+// AutoTC see I18n.AutoTC.
+func (ns *Namespace) AutoTC(r *http.Request, context string, key string, params ...interface{}) string {
+	return ns.i18n.AutoTC(r, context, ns.key(key), params...)
+}
+
+// LoadNamespace load, or reload, the namespace localization files.
+// Namespaces are loaded automatically on first use,
+// call it to load them in advance and check for errors.
+func (i18n *I18n) LoadNamespace(name string) error {
+	localizations, err := i18n.loadNamespace(name)
+
+	i18n.namespacesMutex.Lock()
+	defer i18n.namespacesMutex.Unlock()
+	if i18n.namespaces == nil {
+		i18n.namespaces = make(map[string]MapStore)
+	}
+	i18n.namespaces[name] = localizations
+	i18n.precompile(name, localizations)
+
+	return err
+}
+
+// namespace return the namespace localizations,
+// loading them on first use.
+func (i18n *I18n) namespace(name string) MapStore {
+	i18n.namespacesMutex.RLock()
+	localizations, ok := i18n.namespaces[name]
+	i18n.namespacesMutex.RUnlock()
+	if ok {
+		return localizations
+	}
+
+	i18n.namespacesMutex.Lock()
+	defer i18n.namespacesMutex.Unlock()
+	if localizations, ok = i18n.namespaces[name]; ok {
+		return localizations
+	}
+
+	localizations, err := i18n.loadNamespace(name)
+	if err != nil {
+		i18n.logf("can't load namespace '%s': %s", name, err.Error())
+	}
+
+	if i18n.namespaces == nil {
+		i18n.namespaces = make(map[string]MapStore)
+	}
+	i18n.namespaces[name] = localizations
+	i18n.precompile(name, localizations)
+
+	return localizations
+}
+
+func (i18n *I18n) loadNamespace(name string) (MapStore, error) {
+	return loadLocalizationFiles(i18n.Tags, func(locale string) string {
+		if i18n.Config.NamespacesLayout == NamespacesLayoutLocaleDir {
+			return filepath.Join(i18n.Config.Path, locale, name)
+		}
+		return filepath.Join(i18n.Config.Path, name, locale)
+	})
+}
+
+// findNamespaces return the namespaces found in
+// i18n.Config.Path, following i18n.Config.NamespacesLayout:
+// the directories of Path or the files of the locales directories.
+func (i18n *I18n) findNamespaces() map[string]bool {
+	namespaces := make(map[string]bool)
+	if len(i18n.Config.Path) == 0 {
+		return namespaces
+	}
+
+	if i18n.Config.NamespacesLayout == NamespacesLayoutLocaleDir {
+		for _, locale := range i18n.locales {
+			files, _ := ioutil.ReadDir(filepath.Join(i18n.Config.Path, locale))
+			for _, file := range files {
+				if !file.IsDir() {
+					namespaces[strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))] = true
+				}
+			}
+		}
+		return namespaces
+	}
+
+	files, _ := ioutil.ReadDir(i18n.Config.Path)
+	for _, file := range files {
+		if file.IsDir() {
+			namespaces[file.Name()] = true
+		}
+	}
+	return namespaces
+}
+
+// splitNamespace split `<namespace>:<key>` keys,
+// namespaces are available only when i18n.Config.Path is set
+// and the prefix is a known or loaded namespace,
+// otherwise key is returned as is.
+func (i18n *I18n) splitNamespace(key string) (namespace string, k string) {
+	if len(i18n.Config.Path) == 0 {
+		return "", key
+	}
+
+	i := strings.Index(key, namespaceSeparator)
+	if i <= 0 {
+		return "", key
+	}
+
+	namespace = key[:i]
+	i18n.namespacesMutex.RLock()
+	_, loaded := i18n.namespaces[namespace]
+	known := loaded || i18n.knownNamespaces[namespace]
+	i18n.namespacesMutex.RUnlock()
+	if !known {
+		return "", key
+	}
+	return namespace, key[i+len(namespaceSeparator):]
+}
@@ -0,0 +1,128 @@
+package i18n
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+)
+
+// Pseudo-locales, available when i18n.Config.PseudoLocales is true.
+// Their localizations are generated from the default locale ones,
+// so that untranslated (hardcoded) text, truncated or
+// RTL-unfriendly layouts are easy to spot.
+const (
+	// PseudoLocaleAccented accent every letter and expand the text by about 30%,
+	// e.g.: `Open` -> `[Öþéñ one]`.
+	PseudoLocaleAccented = "en-XA"
+	// PseudoLocaleBidi mirror every word as right-to-left text.
+	PseudoLocaleBidi = "ar-XB"
+)
+
+var pseudoLocales = map[string]func(string) string{
+	PseudoLocaleAccented: pseudoAccented,
+	PseudoLocaleBidi:     pseudoBidi,
+}
+
+// matchPseudoLocale return the pseudo-locale tag
+// if locale is one of the pseudo-locales and they are enabled.
+func (i18n *I18n) matchPseudoLocale(locale string) (language.Tag, bool) {
+	if !i18n.Config.PseudoLocales {
+		return language.Und, false
+	}
+
+	tags, _, _ := language.ParseAcceptLanguage(locale)
+	if len(tags) == 0 {
+		return language.Und, false
+	}
+	if _, ok := pseudoLocales[tags[0].String()]; ok {
+		return tags[0], true
+	}
+	return language.Und, false
+}
+
+var accents = strings.NewReplacer(
+	"a", "å", "b", "ƀ", "c", "ç", "d", "ð", "e", "é", "f", "ƒ", "g", "ĝ", "h", "ĥ", "i", "î",
+	"j", "ĵ", "k", "ķ", "l", "ļ", "m", "ɱ", "n", "ñ", "o", "ö", "p", "þ", "q", "ǫ", "r", "ŕ",
+	"s", "š", "t", "ţ", "u", "û", "v", "ṽ", "w", "ŵ", "x", "ẋ", "y", "ý", "z", "ž",
+	"A", "Å", "B", "Ɓ", "C", "Ç", "D", "Ð", "E", "É", "F", "Ƒ", "G", "Ĝ", "H", "Ĥ", "I", "Î",
+	"J", "Ĵ", "K", "Ķ", "L", "Ļ", "M", "Ṁ", "N", "Ñ", "O", "Ö", "P", "Þ", "Q", "Ǫ", "R", "Ŕ",
+	"S", "Š", "T", "Ţ", "U", "Û", "V", "Ṽ", "W", "Ŵ", "X", "Ẋ", "Y", "Ý", "Z", "Ž",
+)
+
+// expansionWords pad the accented pseudo-localizations.
+var expansionWords = strings.Fields("one two three four five six seven eight nine ten")
+
+func pseudoAccented(text string) string {
+	if len(text) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	b.WriteString("[")
+	b.WriteString(accents.Replace(text))
+
+	expansion := (utf8.RuneCountInString(text)*3 + 9) / 10
+	for i := 0; expansion > 0; i++ {
+		word := expansionWords[i%len(expansionWords)]
+		b.WriteString(" ")
+		b.WriteString(word)
+		expansion -= len(word) + 1
+	}
+
+	b.WriteString("]")
+	return b.String()
+}
+
+func pseudoBidi(text string) string {
+	if len(text) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	b.WriteString("\u200f")
+	for i, word := range strings.Split(text, " ") {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		if len(word) > 0 {
+			b.WriteString("\u202e" + word + "\u202c")
+		}
+	}
+	return b.String()
+}
+
+// pseudoFormat apply pseudo to the text of the fmt format,
+// leaving the verbs untouched: they are replaced by
+// private use runes while pseudo is applied.
+func pseudoFormat(format string, pseudo func(string) string) string {
+	var b strings.Builder
+	var verbs []string
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			b.WriteByte(format[i])
+			continue
+		}
+
+		j := i + 1
+		for j < len(format) && strings.IndexByte("+-# 0123456789.[]*", format[j]) >= 0 {
+			j++
+		}
+		if j < len(format) {
+			j++
+		}
+
+		b.WriteRune(pseudoVerb + rune(len(verbs)))
+		verbs = append(verbs, format[i:j])
+		i = j - 1
+	}
+
+	text := pseudo(b.String())
+	for i, verb := range verbs {
+		text = strings.Replace(text, string(pseudoVerb+rune(i)), verb, 1)
+	}
+	return text
+}
+
+// pseudoVerb is the first private use rune replacing verbs in pseudoFormat.
+const pseudoVerb = '\ue000'
@@ -0,0 +1,117 @@
+package i18n
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// problemTitleVariant and problemDetailVariant are the
+	// optional variants of the WriteProblem keys.
+	problemTitleVariant  = "title"
+	problemDetailVariant = "detail"
+
+	// statusKeyPrefix is the prefix of the optional
+	// localized status texts, e.g.: `http.404`.
+	statusKeyPrefix = "http"
+)
+
+// ErrorResponse is the WriteError response body.
+type ErrorResponse struct {
+	// Code is the untranslated key, for client-side handling.
+	Code string `json:"code"`
+	// Message is the localized key.
+	Message string `json:"message"`
+}
+
+// Problem is the WriteProblem response body,
+// an RFC 7807 problem details object.
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Code is the untranslated key, for client-side handling.
+	Code string `json:"code"`
+}
+
+// WriteError write a JSON ErrorResponse with the given status,
+// the key is localized in the request locale as in AutoT,
+// which is set as the Content-Language.
+// Keys with the WriteProblem variants use the `<key>.detail` one:
+//
+//	# it.yaml
+//	ERR_NOT_FOUND:
+//	  title: "Elemento non trovato"
+//	  detail: "L'elemento %v non esiste"
+//
+//	localizer.WriteError(w, r, http.StatusNotFound, "ERR_NOT_FOUND", id)
+//	// -> {"code":"ERR_NOT_FOUND","message":"L'elemento 42 non esiste"}
+func (i18n *I18n) WriteError(w http.ResponseWriter, r *http.Request, status int, key string, params ...interface{}) {
+	locale := i18n.GetLocale(r)
+	localizations, _, k := i18n.localeLocalizations(locale, key)
+	if detailKey := k + keySeparator + problemDetailVariant; !localizations.has(k) && localizations.has(detailKey) {
+		k = detailKey
+	}
+	i18n.writeJSON(w, locale, "application/json; charset=utf-8", status, ErrorResponse{
+		Code:    key,
+		Message: i18n.sprintf(localizations, locale, k, key, params),
+	})
+}
+
+// WriteProblem write an RFC 7807 `application/problem+json`
+// Problem with the given status, localized in the request
+// locale as in AutoT, which is set as the Content-Language.
+// The title is the `<key>.title` localization (without params),
+// or the `http.<status>` one if missing, otherwise the English
+// http.StatusText, the detail is the `<key>.detail`
+// localization, or the key one, if any:
+//
+//	# it.yaml
+//	ERR_NOT_FOUND:
+//	  title: "Elemento non trovato"
+//	  detail: "L'elemento %v non esiste"
+//	http:
+//	  "400": "Richiesta non valida"
+//
+//	localizer.WriteProblem(w, r, http.StatusNotFound, "ERR_NOT_FOUND", id)
+//	// -> {"title":"Elemento non trovato","status":404,"detail":"L'elemento 42 non esiste",
+//	//     "instance":"/items/42","code":"ERR_NOT_FOUND"}
+func (i18n *I18n) WriteProblem(w http.ResponseWriter, r *http.Request, status int, key string, params ...interface{}) {
+	locale := i18n.GetLocale(r)
+	localizations, _, k := i18n.localeLocalizations(locale, key)
+
+	problem := Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   key,
+	}
+	if titleKey := k + keySeparator + problemTitleVariant; localizations.has(titleKey) {
+		problem.Title = i18n.sprintf(localizations, locale, titleKey, key, nil)
+	} else {
+		statusKey := statusKeyPrefix + keySeparator + strconv.Itoa(status)
+		statusLocalizations, _, k := i18n.localeLocalizations(locale, statusKey)
+		problem.Title = i18n.sprintf(statusLocalizations, locale, k, problem.Title, nil)
+	}
+	if detailKey := k + keySeparator + problemDetailVariant; localizations.has(detailKey) {
+		problem.Detail = i18n.sprintf(localizations, locale, detailKey, key, params)
+	} else if localizations.has(k) {
+		problem.Detail = i18n.sprintf(localizations, locale, k, key, params)
+	}
+	if r != nil {
+		problem.Instance = r.URL.RequestURI()
+	}
+
+	i18n.writeJSON(w, locale, "application/problem+json; charset=utf-8", status, problem)
+}
+
+func (i18n *I18n) writeJSON(w http.ResponseWriter, locale string, contentType string, status int, body interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Language", locale)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		i18n.logf("can't encode response: %s", err.Error())
+	}
+}
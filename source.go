@@ -0,0 +1,62 @@
+package i18n
+
+import "net/http"
+
+// LocaleSource is a source of candidate locales,
+// see i18n.Config.LocaleSources.
+//
+// Candidates are collected from every source, in order,
+// and the first one matched with at least language.Low
+// (or i18n.Config.MinConfidence) confidence is used.
+// HTTPLocalePosition (header, cookie, query, path and host),
+// JWTSource, UserSource, ContextSource and LocaleSourceFunc are the built-in ones:
+//
+//	LocaleSources: []i18n.LocaleSource{
+//		i18n.ContextSource{Key: userLocaleKey},
+//		i18n.HTTPLocalePosition{ID: i18n.HTTPLocalePositionIDCookie, Key: "lang"},
+//		i18n.HTTPLocalePosition{ID: i18n.HTTPLocalePositionIDHeader, Key: "Accept-Language"},
+//		i18n.LocaleSourceFunc(func(*http.Request) []string { return []string{*langFlag} }),
+//	}
+//
+// Candidates not matching any available locale are skipped,
+// so that sources yielding arbitrary values (such as the path
+// or host) can be safely composed with the other ones.
+type LocaleSource interface {
+	// Locales return the candidate locales, or Accept-Language
+	// values, from the most preferred to the least one.
+	// r is nil outside of http requests, e.g.: `localizer.GetLocale(nil)`.
+	Locales(r *http.Request) []string
+}
+
+// LocaleSourceFunc is a func LocaleSource.
+type LocaleSourceFunc func(r *http.Request) []string
+
+// Locales implements LocaleSource.
+func (f LocaleSourceFunc) Locales(r *http.Request) []string {
+	return f(r)
+}
+
+// ContextSource is a LocaleSource reading the locale from
+// the request context value with the given Key,
+// e.g.: set by an authentication middleware from the user profile.
+// The value can be a string or a []string.
+type ContextSource struct {
+	Key interface{}
+}
+
+// Locales implements LocaleSource.
+func (s ContextSource) Locales(r *http.Request) []string {
+	if r == nil {
+		return nil
+	}
+
+	switch v := r.Context().Value(s.Key).(type) {
+	case string:
+		if len(v) > 0 {
+			return []string{v}
+		}
+	case []string:
+		return v
+	}
+	return nil
+}
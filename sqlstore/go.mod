@@ -0,0 +1,22 @@
+module github.com/oblq/i18n/v2/sqlstore
+
+go 1.17
+
+require (
+	github.com/mattn/go-sqlite3 v1.14.16
+	github.com/oblq/i18n/v2 v2.1.0
+	github.com/stretchr/testify v1.7.0
+	golang.org/x/text v0.3.7
+)
+
+require (
+	github.com/BurntSushi/toml v0.4.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/oblq/swap v1.0.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+)
+
+// Develop against the core module in the parent directory,
+// the require above is the core version used by dependents.
+replace github.com/oblq/i18n/v2 => ../
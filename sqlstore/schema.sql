@@ -0,0 +1,13 @@
+-- i18n_localizations holds the localizations read by sqlstore.Store.
+-- plural_category is empty for plain keys, otherwise it is one of
+-- zero, one, two, few, many or other (as in localization files plural variants).
+-- updated_at must be set on every insert and update,
+-- it is used to detect changes.
+CREATE TABLE IF NOT EXISTS i18n_localizations (
+	locale          VARCHAR(35)  NOT NULL,
+	message_key     VARCHAR(255) NOT NULL,
+	message_value   TEXT         NOT NULL,
+	plural_category VARCHAR(5)   NOT NULL DEFAULT '',
+	updated_at      TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (locale, message_key, plural_category)
+);
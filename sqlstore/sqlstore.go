@@ -0,0 +1,233 @@
+// Package sqlstore is a database/sql i18n.Store.
+//
+// Localizations are stored in a table with the schema in schema.sql
+// (see Migrate), one row per locale, key and plural category:
+//
+//	INSERT INTO i18n_localizations (locale, message_key, message_value, plural_category)
+//	VALUES ('en', 'ITEMS', '%d item', 'one'), ('en', 'ITEMS', '%d items', 'other');
+//
+// The store is refreshed periodically, so that localizations
+// edited in the database are picked up by running services:
+//
+//	store, err := sqlstore.New(db, &sqlstore.Config{RefreshInterval: time.Minute})
+//	localizer, err := i18n.NewWithConfig(&i18n.Config{
+//		Locales: []string{"en", "it"},
+//		Path:    "./localizations",
+//		Layers:  []i18n.Layer{{Name: "db", Store: store}},
+//	})
+package sqlstore
+
+import (
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oblq/i18n/v2"
+)
+
+// DefaultTable is the default localizations table name.
+const DefaultTable = "i18n_localizations"
+
+// Schema is the localizations table schema for DefaultTable.
+//
+//go:embed schema.sql
+var Schema string
+
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Config is the sqlstore config struct.
+type Config struct {
+	// Table is the localizations table name.
+	// Default is DefaultTable.
+	Table string
+
+	// RefreshInterval is the interval at which the table is
+	// checked for changes, using the updated_at column
+	// and the rows count (to detect deleted rows).
+	// Zero disables the periodic refresh, see Store.Refresh.
+	RefreshInterval time.Duration
+
+	// Logger is used to report refresh failures,
+	// nothing is logged if nil.
+	Logger i18n.Logger
+}
+
+// Store is a database/sql i18n.Store,
+// localizations are cached in memory and
+// reloaded when the table changes.
+type Store struct {
+	db     *sql.DB
+	config Config
+
+	mutex         sync.RWMutex
+	localizations i18n.MapStore
+	version       string
+	onChange      []func()
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Migrate create the localizations table if it does not exist.
+// An empty table is DefaultTable.
+func Migrate(db *sql.DB, table string) error {
+	if len(table) == 0 {
+		table = DefaultTable
+	}
+	if !tableName.MatchString(table) {
+		return fmt.Errorf("invalid table name '%s'", table)
+	}
+
+	if _, err := db.Exec(strings.ReplaceAll(Schema, DefaultTable, table)); err != nil {
+		return fmt.Errorf("can't create table '%s': %w", table, err)
+	}
+	return nil
+}
+
+// New create a new Store and load the localizations,
+// config can be nil.
+// Close must be called to stop the periodic refresh.
+func New(db *sql.DB, config *Config) (*Store, error) {
+	s := &Store{db: db}
+	if config != nil {
+		s.config = *config
+	}
+
+	if len(s.config.Table) == 0 {
+		s.config.Table = DefaultTable
+	}
+	if !tableName.MatchString(s.config.Table) {
+		return nil, fmt.Errorf("invalid table name '%s'", s.config.Table)
+	}
+
+	if _, err := s.Refresh(); err != nil {
+		return nil, err
+	}
+
+	if s.config.RefreshInterval > 0 {
+		s.stop = make(chan struct{})
+		s.done = make(chan struct{})
+		go s.refreshLoop()
+	}
+
+	return s, nil
+}
+
+// Close stop the periodic refresh, the database is not closed.
+func (s *Store) Close() error {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+		s.stop = nil
+	}
+	return nil
+}
+
+func (s *Store) refreshLoop() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			if _, err := s.Refresh(); err != nil && s.config.Logger != nil {
+				s.config.Logger.Printf("[i18n] sqlstore refresh failed: %s", err.Error())
+			}
+		}
+	}
+}
+
+// Refresh reload the localizations if the table changed since
+// the last load, Watch callbacks are called if it did.
+func (s *Store) Refresh() (changed bool, err error) {
+	var count int64
+	var updatedAt sql.NullString
+	query := fmt.Sprintf("SELECT COUNT(*), MAX(updated_at) FROM %s", s.config.Table)
+	if err = s.db.QueryRow(query).Scan(&count, &updatedAt); err != nil {
+		return false, fmt.Errorf("can't check table '%s': %w", s.config.Table, err)
+	}
+
+	version := fmt.Sprintf("%d/%s", count, updatedAt.String)
+	s.mutex.RLock()
+	changed = version != s.version
+	s.mutex.RUnlock()
+	if !changed {
+		return false, nil
+	}
+
+	localizations, err := s.load()
+	if err != nil {
+		return false, err
+	}
+
+	s.mutex.Lock()
+	s.localizations = localizations
+	s.version = version
+	onChange := s.onChange
+	s.mutex.Unlock()
+
+	for _, f := range onChange {
+		f()
+	}
+	return true, nil
+}
+
+func (s *Store) load() (i18n.MapStore, error) {
+	query := fmt.Sprintf("SELECT locale, message_key, message_value, plural_category FROM %s", s.config.Table)
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("can't load table '%s': %w", s.config.Table, err)
+	}
+	defer rows.Close()
+
+	localizations := make(i18n.MapStore)
+	for rows.Next() {
+		var locale, key, value, pluralCategory string
+		if err = rows.Scan(&locale, &key, &value, &pluralCategory); err != nil {
+			return nil, fmt.Errorf("can't load table '%s': %w", s.config.Table, err)
+		}
+
+		if len(pluralCategory) > 0 {
+			key += "." + pluralCategory
+		}
+		if localizations[locale] == nil {
+			localizations[locale] = make(map[string]string)
+		}
+		localizations[locale][key] = value
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("can't load table '%s': %w", s.config.Table, err)
+	}
+	return localizations, nil
+}
+
+// Lookup implements i18n.Store.
+func (s *Store) Lookup(locale string, key string) (string, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.localizations.Lookup(locale, key)
+}
+
+// Keys implements i18n.Store.
+func (s *Store) Keys(locale string) []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.localizations.Keys(locale)
+}
+
+// Watch implements i18n.Store,
+// onChange is called after every Refresh loading changes.
+func (s *Store) Watch(onChange func()) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.onChange = append(s.onChange, onChange)
+}
@@ -0,0 +1,76 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/oblq/i18n/v2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestStore(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.Equal(t, nil, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	assert.NotEqual(t, nil, Migrate(db, "bad name"))
+	assert.Equal(t, nil, Migrate(db, ""))
+	_, err = db.Exec(`INSERT INTO i18n_localizations (locale, message_key, message_value, plural_category)
+		VALUES ('en', 'ITEMS', '%d item', 'one'), ('en', 'ITEMS', '%d items', 'other'), ('it', 'OPEN', 'Apri', '')`)
+	assert.Equal(t, nil, err)
+
+	store, err := New(db, &Config{RefreshInterval: 10 * time.Millisecond})
+	assert.Equal(t, nil, err)
+	defer store.Close()
+
+	localizer, err := i18n.NewWithConfig(&i18n.Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Layers:  []i18n.Layer{{Name: "db", Store: store}},
+	})
+	assert.Equal(t, nil, err)
+
+	assert.Equal(t, "2 items", localizer.TP("en", "ITEMS", 2))
+	assert.Equal(t, "Apri", localizer.T("it", "OPEN"))
+	assert.Equal(t, []string{"ITEMS.one", "ITEMS.other"}, store.Keys("en"))
+
+	changed := make(chan struct{}, 1)
+	store.Watch(func() { changed <- struct{}{} })
+
+	_, err = db.Exec(`UPDATE i18n_localizations SET message_value = 'Apri ora', updated_at = ? WHERE locale = 'it'`,
+		time.Now().Add(time.Hour))
+	assert.Equal(t, nil, err)
+
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatal("store not refreshed")
+	}
+	assert.Equal(t, "Apri ora", localizer.T("it", "OPEN"))
+
+	refreshed, err := store.Refresh()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, false, refreshed)
+
+	_, err = db.Exec(`DELETE FROM i18n_localizations WHERE plural_category = 'one'`)
+	assert.Equal(t, nil, err)
+	store.Close()
+	refreshed, err = store.Refresh()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, true, refreshed)
+	assert.Equal(t, "1 items", localizer.TP("en", "ITEMS", 1))
+
+	_, err = New(db, &Config{Table: "missing"})
+	assert.NotEqual(t, nil, err)
+
+	assert.Equal(t, nil, Migrate(db, "custom_localizations"))
+	_, err = db.Exec(`INSERT INTO custom_localizations (locale, message_key, message_value) VALUES ('en', 'OPEN', 'Open')`)
+	assert.Equal(t, nil, err)
+	custom, err := New(db, &Config{Table: "custom_localizations"})
+	assert.Equal(t, nil, err)
+	defer custom.Close()
+	assert.Equal(t, []string{"OPEN"}, custom.Keys("en"))
+}
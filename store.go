@@ -0,0 +1,143 @@
+package i18n
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/oblq/swap"
+	"golang.org/x/text/language"
+)
+
+// Store is a localizations storage backend,
+// keys are flat, as in hardcoded localizations
+// (e.g.: `ITEMS.one`, `@status.OPEN`).
+//
+// MapStore, used for hardcoded localizations and
+// localization files, is the built-in implementation,
+// implement it to load localizations from a database,
+// a cache or a CMS and use it as a Layer:
+//
+//	Layers: []i18n.Layer{{Name: "cms", Store: myCMSStore}}
+type Store interface {
+	// Lookup return the localization of key for locale,
+	// ok is false if key is not localized in locale.
+	Lookup(locale string, key string) (localization string, ok bool)
+
+	// Keys return the keys localized in locale.
+	Keys(locale string) []string
+
+	// Watch register onChange to be called
+	// every time the store localizations change.
+	// Stores that never change can ignore it.
+	Watch(onChange func())
+}
+
+// MapStore is an in-memory Store:
+// MapStore[<language>][<key>] -> Localization.
+type MapStore map[string]map[string]string
+
+// Lookup implements Store.
+func (s MapStore) Lookup(locale string, key string) (string, bool) {
+	localization, ok := s[locale][key]
+	return localization, ok
+}
+
+// Keys implements Store.
+func (s MapStore) Keys(locale string) []string {
+	keys := make([]string, 0, len(s[locale]))
+	for key := range s[locale] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Watch implements Store, a MapStore never changes.
+func (s MapStore) Watch(func()) {}
+
+// NewFileStore load the localization files in path
+// for the given locales into a MapStore,
+// see I18n.LoadLocalizationFiles.
+func NewFileStore(path string, locales ...string) (MapStore, error) {
+	tags, err := parseLocalesToTags(locales)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadLocalizationFiles(tags, func(locale string) string {
+		return filepath.Join(path, locale)
+	})
+}
+
+// loadLocalizationFiles load the localization files
+// for tags, fileName return the file path
+// (without extension) for a given locale.
+// All the files are loaded anyway, failures are
+// returned together as LocalizationFilesError.
+func loadLocalizationFiles(tags []language.Tag, fileName func(locale string) string) (MapStore, error) {
+	localizations := make(MapStore)
+
+	var filesErr LocalizationFilesError
+	for _, lang := range tags {
+		var nestedLocalizations map[string]interface{}
+		locFileName := fileName(lang.String())
+		if err := swap.Parse(&nestedLocalizations, locFileName); err != nil {
+			filesErr = append(filesErr, &LocalizationFileError{Locale: lang.String(), Path: locFileName, Err: err})
+			continue
+		}
+
+		langLocalizations := make(map[string]string)
+		flatten("", nestedLocalizations, langLocalizations)
+		localizations[lang.String()] = langLocalizations
+	}
+
+	if len(filesErr) > 0 {
+		return localizations, filesErr
+	}
+	return localizations, nil
+}
+
+// localeStore bind a Store, and its namespace, to a locale.
+type localeStore struct {
+	store     Store
+	namespace string
+	locale    string
+	messages  *messageCache
+
+	// source is the locale looked up in store for
+	// pseudo-locales, pseudo generate their localizations.
+	source string
+	pseudo func(string) string
+}
+
+// message return the compiled localization of key.
+func (s localeStore) message(key string) (*compiledMessage, bool) {
+	k := messageKey{s.namespace, s.locale, key}
+	if m, ok := s.messages.get(k); ok {
+		return m, true
+	}
+
+	if s.store == nil {
+		return nil, false
+	}
+	locale := s.locale
+	if s.pseudo != nil {
+		locale = s.source
+	}
+	localization, ok := s.store.Lookup(locale, key)
+	if !ok {
+		return nil, false
+	}
+	if s.pseudo != nil {
+		localization = pseudoFormat(localization, s.pseudo)
+	}
+
+	m := compile(localization)
+	s.messages.put(k, m)
+	return m, true
+}
+
+func (s localeStore) has(key string) bool {
+	_, ok := s.message(key)
+	return ok
+}
@@ -1,20 +1,101 @@
 package i18n
 
 import (
-	"fmt"
 	"net/http"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+const (
+	// keySeparator joins nested localization keys,
+	// e.g.: `WELCOME.female` or `ITEMS.one`.
+	keySeparator = "."
+
+	// otherVariant is the fallback variant for both
+	// select and plural variants.
+	otherVariant = "other"
+
+	// contextPrefix marks the localization file sections
+	// holding the keys of a given context, e.g.: `@button`.
+	contextPrefix = "@"
 )
 
+// pluralForms are the plural.Form names used as plural variants.
+var pluralForms = []string{
+	plural.Other: "other",
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+}
+
 func (i18n *I18n) translate(locale string, key string, params ...interface{}) string {
-	var localeLocalizations map[string]string
-	var ok bool
-	if localeLocalizations, ok = i18n.localizations[locale]; !ok {
-		availableLocale := i18n.MatchAvailableLanguageTag(locale)
-		localeLocalizations, _ = i18n.localizations[availableLocale.String()]
+	localizations, _, k := i18n.localeLocalizations(locale, key)
+	return i18n.sprintf(localizations, locale, k, key, params)
+}
+
+// sprintf format the localization of key with params,
+// fallback is returned if key is not in localizations.
+func (i18n *I18n) sprintf(localizations localeStore, locale, key, fallback string, params []interface{}) string {
+	if m, ok := localizations.message(key); ok {
+		return m.render(i18n.localizeParams(locale, params))
+	}
+	return fallback
+}
+
+// localeLocalizations return the localizations for locale,
+// or for the available locale matching it, of the namespace
+// of key (see Namespace), plus the key without namespace.
+func (i18n *I18n) localeLocalizations(locale string, key string) (localeStore, language.Tag, string) {
+	localizations := localeStore{store: &i18n.layers, messages: &i18n.messages}
+	if namespace, k := i18n.splitNamespace(key); len(namespace) > 0 {
+		localizations.store, localizations.namespace, key = i18n.namespace(namespace), namespace, k
+	}
+
+	for i, l := range i18n.locales {
+		if l == locale {
+			localizations.locale = locale
+			return localizations, i18n.Tags[i], key
+		}
 	}
 
-	if localization, ok := localeLocalizations[key]; ok {
-		return fmt.Sprintf(localization, params...)
+	availableLocale := i18n.MatchAvailableLanguageTag(locale)
+	localizations.locale = availableLocale.String()
+	if pseudo, ok := pseudoLocales[localizations.locale]; ok && i18n.Config.PseudoLocales {
+		localizations.source, localizations.pseudo = i18n.locales[0], pseudo
+	}
+	return localizations, availableLocale, key
+}
+
+// variantKey return key.variant if it exists,
+// otherwise key.other if it exists, otherwise key.
+func variantKey(localizations localeStore, key string, variant string) string {
+	for _, k := range []string{key + keySeparator + variant, key + keySeparator + otherVariant} {
+		if localizations.has(k) {
+			return k
+		}
+	}
+	return key
+}
+
+// contextKey return the localization key of key in the given context.
+func contextKey(context string, key string) string {
+	return contextPrefix + context + keySeparator + key
+}
+
+// pluralKey return the plural variant of key for the first
+// integer param, key is returned if there is no integer param.
+func pluralKey(localizations localeStore, tag language.Tag, key string, params []interface{}) string {
+	for _, param := range params {
+		if n, ok := integer(param); ok {
+			if n < 0 {
+				n = -n
+			}
+			form := plural.Cardinal.MatchPlural(tag, n, 0, 0, 0, 0)
+			return variantKey(localizations, key, pluralForms[form])
+		}
 	}
 	return key
 }
@@ -32,7 +113,7 @@ func (i18n *I18n) T(locale string, key string, params ...interface{}) string {
 // then in 'Accept-Language' header.
 func (i18n *I18n) AutoT(r *http.Request, key string, params ...interface{}) string {
 	if r == nil {
-		fmt.Println("[i18n] http request nil, key:", key)
+		i18n.logf("http request nil, key: %s", key)
 		return key
 	}
 	locale := i18n.GetLocale(r)
@@ -41,8 +122,18 @@ func (i18n *I18n) AutoT(r *http.Request, key string, params ...interface{}) stri
 
 // TP translate the key based on the passed locale
 // and for possibly plural values.
+// The plural form (zero, one, two, few, many or other)
+// of the first integer param in the given locale is used
+// to select the plural variant of the key:
+//
+//	// en.yaml -> ITEMS: {one: "%d item", other: "%d items"}
+//	localizer.TP("en", "ITEMS", 2) // -> "2 items"
+//
+// key.other is used if the plural form is not defined,
+// key is used if no plural variant is defined at all.
 func (i18n *I18n) TP(locale string, key string, params ...interface{}) string {
-	return i18n.translate(locale, key, params...)
+	localizations, tag, k := i18n.localeLocalizations(locale, key)
+	return i18n.sprintf(localizations, locale, pluralKey(localizations, tag, k, params), key, params)
 }
 
 // AutoTP automatically translate the key based on the
@@ -52,9 +143,76 @@ func (i18n *I18n) TP(locale string, key string, params ...interface{}) string {
 // then in 'Accept-Language' header.
 func (i18n *I18n) AutoTP(r *http.Request, key string, params ...interface{}) string {
 	if r == nil {
-		fmt.Println("[i18n] http request nil, key:", key)
+		i18n.logf("http request nil, key: %s", key)
 		return key
 	}
 	locale := i18n.GetLocale(r)
-	return i18n.translate(locale, key, params...)
+	return i18n.TP(locale, key, params...)
+}
+
+// TS translate the select variant of the key based
+// on the passed locale, e.g.: for gender variants:
+//
+//	// it.yaml -> WELCOME: {male: "Benvenuto %s", female: "Benvenuta %s", other: "Ciao %s"}
+//	localizer.TS("it", "WELCOME", "female", "Anna") // -> "Benvenuta Anna"
+//
+// key.other is used if the selector variant is not defined.
+// The variant is then translated as in TP, so that
+// it can have plural variants too (e.g.: `WELCOME.female.one`).
+func (i18n *I18n) TS(locale string, key string, selector string, params ...interface{}) string {
+	localizations, tag, k := i18n.localeLocalizations(locale, key)
+	for _, variant := range []string{selector, otherVariant} {
+		vk := pluralKey(localizations, tag, k+keySeparator+variant, params)
+		if localizations.has(vk) {
+			return i18n.sprintf(localizations, locale, vk, key, params)
+		}
+	}
+	return i18n.TP(locale, key, params...)
+}
+
+// AutoTS automatically translate the select variant
+// of the key based on the http request, see TS and AutoT.
+func (i18n *I18n) AutoTS(r *http.Request, key string, selector string, params ...interface{}) string {
+	if r == nil {
+		i18n.logf("http request nil, key: %s", key)
+		return key
+	}
+	locale := i18n.GetLocale(r)
+	return i18n.TS(locale, key, selector, params...)
+}
+
+// TC translate the key in the given context based on the passed locale,
+// it disambiguates identical keys with different meanings,
+// e.g.: the "Open" button and the "Open" status.
+// Contexts are defined as localization file sections
+// named as the context prefixed by `@`:
+//
+//	# it.yaml
+//	Open: "Apri"
+//	"@status":
+//	  Open: "Aperto"
+//
+//	localizer.TC("it", "status", "Open") // -> "Aperto"
+//	localizer.TC("it", "button", "Open") // -> "Apri"
+//
+// The key without context is used if it is not defined in context.
+// Plural variants are supported as in TP.
+func (i18n *I18n) TC(locale string, context string, key string, params ...interface{}) string {
+	localizations, tag, k := i18n.localeLocalizations(locale, key)
+	ck := pluralKey(localizations, tag, contextKey(context, k), params)
+	if localizations.has(ck) {
+		return i18n.sprintf(localizations, locale, ck, key, params)
+	}
+	return i18n.TP(locale, key, params...)
+}
+
+// AutoTC automatically translate the key in the given
+// context based on the http request, see TC and AutoT.
+func (i18n *I18n) AutoTC(r *http.Request, context string, key string, params ...interface{}) string {
+	if r == nil {
+		i18n.logf("http request nil, key: %s", key)
+		return key
+	}
+	locale := i18n.GetLocale(r)
+	return i18n.TC(locale, context, key, params...)
 }
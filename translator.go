@@ -0,0 +1,116 @@
+package i18n
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/number"
+)
+
+// Translator is bound to one of the available locales,
+// it can be passed around in place of the i18n instance
+// and the locale, e.g.: to templates.
+type Translator struct {
+	// Tag is the resolved language.Tag.
+	Tag language.Tag
+
+	i18n *I18n
+}
+
+// Translator return a Translator bound to the available
+// language.Tag matching locale (see MatchAvailableLanguageTag).
+func (i18n *I18n) Translator(locale string) *Translator {
+	return &Translator{Tag: i18n.MatchAvailableLanguageTag(locale), i18n: i18n}
+}
+
+// AutoTranslator return a Translator bound to the http request locale.
+func (i18n *I18n) AutoTranslator(r *http.Request) *Translator {
+	return &Translator{Tag: i18n.GetLanguageTag(r), i18n: i18n}
+}
+
+// Locale return t.Tag.String().
+func (t *Translator) Locale() string {
+	return t.Tag.String()
+}
+
+// Direction return the text direction of the translator locale.
+func (t *Translator) Direction() Direction {
+	return direction(t.Tag)
+}
+
+// T translate the key, see I18n.T.
+func (t *Translator) T(key string, params ...interface{}) string {
+	return t.i18n.translate(t.Locale(), key, params...)
+}
+
+// TP translate the key for possibly plural values, see I18n.TP.
+func (t *Translator) TP(key string, params ...interface{}) string {
+	return t.i18n.TP(t.Locale(), key, params...)
+}
+
+// TS translate the select variant of the key, see I18n.TS.
+func (t *Translator) TS(key string, selector string, params ...interface{}) string {
+	return t.i18n.TS(t.Locale(), key, selector, params...)
+}
+
+// TC translate the key in the given context, see I18n.TC.
+func (t *Translator) TC(context string, key string, params ...interface{}) string {
+	return t.i18n.TC(t.Locale(), context, key, params...)
+}
+
+// Render translate msg, see I18n.Render.
+func (t *Translator) Render(msg Message) string {
+	return t.i18n.Render(t.Locale(), msg)
+}
+
+// FormatNumber see I18n.FormatNumber.
+func (t *Translator) FormatNumber(n interface{}, options ...number.Option) string {
+	return t.i18n.FormatNumber(t.Locale(), n, options...)
+}
+
+// FormatPercent see I18n.FormatPercent.
+func (t *Translator) FormatPercent(n interface{}, options ...number.Option) string {
+	return t.i18n.FormatPercent(t.Locale(), n, options...)
+}
+
+// FormatCurrency see I18n.FormatCurrency.
+func (t *Translator) FormatCurrency(amount float64, unit currency.Unit) string {
+	return formatCurrency(t.Tag, amount, unit)
+}
+
+// FormatDate see I18n.FormatDate.
+func (t *Translator) FormatDate(date time.Time, style DateStyle) string {
+	return cldrLocaleForTag(t.Tag).formatDate(date, style)
+}
+
+// FormatTime see I18n.FormatTime.
+func (t *Translator) FormatTime(date time.Time, style DateStyle) string {
+	return cldrLocaleForTag(t.Tag).formatTime(date, style)
+}
+
+// FormatDateTime see I18n.FormatDateTime.
+func (t *Translator) FormatDateTime(date time.Time, style DateStyle) string {
+	return cldrLocaleForTag(t.Tag).formatDateTime(date, style)
+}
+
+// FormatRelativeTime see I18n.FormatRelativeTime.
+func (t *Translator) FormatRelativeTime(d time.Duration) string {
+	return formatRelativeTime(t.Tag, d)
+}
+
+// FormatList see I18n.FormatList.
+func (t *Translator) FormatList(items []string, style ListStyle) string {
+	return formatList(t.Tag, items, style)
+}
+
+// FormatUnit see I18n.FormatUnit.
+func (t *Translator) FormatUnit(value interface{}, unit Unit) string {
+	return formatUnit(t.Tag, value, unit)
+}
+
+// FormatDuration see I18n.FormatDuration.
+func (t *Translator) FormatDuration(d time.Duration) string {
+	return formatDuration(t.Tag, d)
+}
@@ -0,0 +1,50 @@
+package i18n
+
+import "net/http"
+
+// Transport is an http.RoundTripper propagating the locale set
+// in the request context by Middleware to outgoing requests,
+// e.g.: from an API gateway to the downstream services.
+//
+//	client := &http.Client{Transport: &i18n.Transport{}}
+//	req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, url, nil)
+//	resp, err := client.Do(req) // -> Accept-Language: <request locale>
+type Transport struct {
+	// Base is the wrapped http.RoundTripper.
+	// Default is http.DefaultTransport.
+	Base http.RoundTripper
+
+	// Positions are the positions the locale is set in,
+	// e.g.: the downstream services i18n.Config.HTTPLookUpStrategy.
+	// Positions already holding a locale are left untouched.
+	// Default is the `Accept-Language` header.
+	Positions []HTTPLocalePosition
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	locale, ok := r.Context().Value(MiddlewareContextLocaleKey).(string)
+	if !ok || len(locale) == 0 {
+		return base.RoundTrip(r)
+	}
+
+	positions := t.Positions
+	if len(positions) == 0 {
+		positions = []HTTPLocalePosition{{HTTPLocalePositionIDHeader, "Accept-Language"}}
+	}
+
+	// a RoundTripper must not modify the request
+	r = r.Clone(r.Context())
+	for _, position := range positions {
+		if len(position.locale(r)) == 0 {
+			position.setLocale(r, locale)
+		}
+	}
+
+	return base.RoundTrip(r)
+}
@@ -0,0 +1,261 @@
+package i18n
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// Unit is a unit of measurement.
+type Unit int
+
+const (
+	UnitSecond Unit = iota
+	UnitMinute
+	UnitHour
+	UnitDay
+	UnitWeek
+	UnitMonth
+	UnitYear
+	UnitMeter
+	UnitKilometer
+	UnitGram
+	UnitKilogram
+	UnitLiter
+	numUnits
+)
+
+// unitSymbols holds the symbol of the measurement units,
+// time units are spelled out using unitNames.
+var unitSymbols = [numUnits]string{
+	UnitMeter:     "m",
+	UnitKilometer: "km",
+	UnitGram:      "g",
+	UnitKilogram:  "kg",
+	UnitLiter:     "L",
+}
+
+// unitNames holds the CLDR long unit patterns
+// (plural forms one and other) by base language,
+// only en, it, de, fr, es and pt are supported,
+// unlisted languages use English.
+var unitNames = map[string][numUnits][2]string{
+	"en": {
+		{"{0} second", "{0} seconds"},
+		{"{0} minute", "{0} minutes"},
+		{"{0} hour", "{0} hours"},
+		{"{0} day", "{0} days"},
+		{"{0} week", "{0} weeks"},
+		{"{0} month", "{0} months"},
+		{"{0} year", "{0} years"},
+		{"{0} meter", "{0} meters"},
+		{"{0} kilometer", "{0} kilometers"},
+		{"{0} gram", "{0} grams"},
+		{"{0} kilogram", "{0} kilograms"},
+		{"{0} liter", "{0} liters"},
+	},
+	"it": {
+		{"{0} secondo", "{0} secondi"},
+		{"{0} minuto", "{0} minuti"},
+		{"{0} ora", "{0} ore"},
+		{"{0} giorno", "{0} giorni"},
+		{"{0} settimana", "{0} settimane"},
+		{"{0} mese", "{0} mesi"},
+		{"{0} anno", "{0} anni"},
+		{"{0} metro", "{0} metri"},
+		{"{0} chilometro", "{0} chilometri"},
+		{"{0} grammo", "{0} grammi"},
+		{"{0} chilogrammo", "{0} chilogrammi"},
+		{"{0} litro", "{0} litri"},
+	},
+	"de": {
+		{"{0} Sekunde", "{0} Sekunden"},
+		{"{0} Minute", "{0} Minuten"},
+		{"{0} Stunde", "{0} Stunden"},
+		{"{0} Tag", "{0} Tage"},
+		{"{0} Woche", "{0} Wochen"},
+		{"{0} Monat", "{0} Monate"},
+		{"{0} Jahr", "{0} Jahre"},
+		{"{0} Meter", "{0} Meter"},
+		{"{0} Kilometer", "{0} Kilometer"},
+		{"{0} Gramm", "{0} Gramm"},
+		{"{0} Kilogramm", "{0} Kilogramm"},
+		{"{0} Liter", "{0} Liter"},
+	},
+	"fr": {
+		{"{0} seconde", "{0} secondes"},
+		{"{0} minute", "{0} minutes"},
+		{"{0} heure", "{0} heures"},
+		{"{0} jour", "{0} jours"},
+		{"{0} semaine", "{0} semaines"},
+		{"{0} mois", "{0} mois"},
+		{"{0} an", "{0} ans"},
+		{"{0} mètre", "{0} mètres"},
+		{"{0} kilomètre", "{0} kilomètres"},
+		{"{0} gramme", "{0} grammes"},
+		{"{0} kilogramme", "{0} kilogrammes"},
+		{"{0} litre", "{0} litres"},
+	},
+	"es": {
+		{"{0} segundo", "{0} segundos"},
+		{"{0} minuto", "{0} minutos"},
+		{"{0} hora", "{0} horas"},
+		{"{0} día", "{0} días"},
+		{"{0} semana", "{0} semanas"},
+		{"{0} mes", "{0} meses"},
+		{"{0} año", "{0} años"},
+		{"{0} metro", "{0} metros"},
+		{"{0} kilómetro", "{0} kilómetros"},
+		{"{0} gramo", "{0} gramos"},
+		{"{0} kilogramo", "{0} kilogramos"},
+		{"{0} litro", "{0} litros"},
+	},
+	"pt": {
+		{"{0} segundo", "{0} segundos"},
+		{"{0} minuto", "{0} minutos"},
+		{"{0} hora", "{0} horas"},
+		{"{0} dia", "{0} dias"},
+		{"{0} semana", "{0} semanas"},
+		{"{0} mês", "{0} meses"},
+		{"{0} ano", "{0} anos"},
+		{"{0} metro", "{0} metros"},
+		{"{0} quilômetro", "{0} quilômetros"},
+		{"{0} grama", "{0} gramas"},
+		{"{0} quilograma", "{0} quilogramas"},
+		{"{0} litro", "{0} litros"},
+	},
+}
+
+// FormatUnit format value (any integer or float type) in the given unit,
+// measurement units use their symbol, e.g.: `5 km`,
+// time units are spelled out, e.g.: `2 hours` (en) or `2 ore` (it),
+// choosing the plural form of value as printed (e.g.: `1.5 hours`).
+// Unit names are localized in en, it, de, fr, es and pt,
+// other languages use the English names with localized numbers.
+// Unknown units format value alone.
+func (i18n *I18n) FormatUnit(locale string, value interface{}, unit Unit) string {
+	return formatUnit(i18n.MatchAvailableLanguageTag(locale), value, unit)
+}
+
+// FormatDuration humanize d listing all of its non-zero
+// days, hours, minutes and seconds, rounded to the second,
+// e.g.: `2 hours, 3 minutes` (en) or `2 ore e 3 minuti` (it).
+// It is localized in the same languages as FormatUnit.
+// The sign of d is ignored, -5s is `5 seconds`,
+// use FormatRelativeTime for past and future durations.
+func (i18n *I18n) FormatDuration(locale string, d time.Duration) string {
+	return formatDuration(i18n.MatchAvailableLanguageTag(locale), d)
+}
+
+func formatUnit(tag language.Tag, value interface{}, unit Unit) string {
+	if unit < 0 || unit >= numUnits {
+		return formatNumber(tag, value)
+	}
+
+	if symbol := unitSymbols[unit]; len(symbol) > 0 {
+		return formatNumber(tag, value) + "\u00a0" + symbol
+	}
+
+	base, _ := tag.Base()
+	names, ok := unitNames[base.String()]
+	if !ok {
+		names = unitNames["en"]
+	}
+
+	pattern := names[unit][1]
+	if i, v, f, ok := pluralOperands(value); ok && plural.Cardinal.MatchPlural(tag, i, v, v, f, f) == plural.One {
+		pattern = names[unit][0]
+	}
+	return strings.Replace(pattern, "{0}", formatNumber(tag, value), 1)
+}
+
+func formatDuration(tag language.Tag, d time.Duration) string {
+	d = d.Round(time.Second)
+	if d < 0 {
+		d = -d
+	}
+
+	var items []string
+	for _, u := range []struct {
+		unit     Unit
+		duration time.Duration
+	}{
+		{UnitDay, 24 * time.Hour},
+		{UnitHour, time.Hour},
+		{UnitMinute, time.Minute},
+		{UnitSecond, time.Second},
+	} {
+		if n := int(d / u.duration); n > 0 {
+			items = append(items, formatUnit(tag, n, u.unit))
+			d -= time.Duration(n) * u.duration
+		}
+	}
+
+	if len(items) == 0 {
+		return formatUnit(tag, 0, UnitSecond)
+	}
+	return formatList(tag, items, ListStyleUnit)
+}
+
+// pluralOperands return the absolute integer digits (i), the number
+// of fraction digits (v) and the fraction digits (f) of value
+// as printed by formatNumber, with at most 3 fraction digits
+// and no trailing zeros, e.g.: 1.50 -> 1, 1, 5.
+func pluralOperands(value interface{}) (i, v, f int, ok bool) {
+	var x float64
+	switch n := value.(type) {
+	case float32:
+		x = float64(n)
+	case float64:
+		x = n
+	default:
+		if i, ok = integer(value); ok && i < 0 {
+			i = -i
+		}
+		return i, 0, 0, ok
+	}
+
+	digits := strings.TrimRight(strconv.FormatFloat(math.Abs(x), 'f', 3, 64), "0")
+	dot := strings.IndexByte(digits, '.')
+	if dot < 0 { // NaN or Inf
+		return 0, 0, 0, false
+	}
+	fraction := digits[dot+1:]
+	if len(fraction) > 0 {
+		f, _ = strconv.Atoi(fraction)
+	}
+
+	i, err := strconv.Atoi(digits[:dot])
+	return i, len(fraction), f, err == nil
+}
+
+// integer return value as int if it is of an integer type.
+func integer(value interface{}) (int, bool) {
+	switch n := value.(type) {
+	case int:
+		return n, true
+	case int8:
+		return int(n), true
+	case int16:
+		return int(n), true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	case uint:
+		return int(n), true
+	case uint8:
+		return int(n), true
+	case uint16:
+		return int(n), true
+	case uint32:
+		return int(n), true
+	case uint64:
+		return int(n), true
+	}
+	return 0, false
+}
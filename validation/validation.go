@@ -0,0 +1,255 @@
+// Package validation translates structured validation failures,
+// such as the github.com/go-playground/validator ones,
+// using i18n localizations.
+//
+// Messages are localized with the `validation.<rule>` keys,
+// the localized field name is the first param, and the rule
+// param (if any) the second one, passed only to messages
+// with a second verb, `validation.default` is used
+// for the rules not localized. Field names are localized
+// with the `fields.<field>` keys, if present:
+//
+//	# en.yaml
+//	validation:
+//	  required: "%s is required"
+//	  min: "%s must be at least %s characters long"
+//	  default: "%s is not valid"
+//	fields:
+//	  email: "Email address"
+//
+//	translator := &validation.Translator{I18n: localizer}
+//	if err := validate.Struct(req); err != nil {
+//		json.NewEncoder(w).Encode(translator.AutoTranslate(r, err))
+//		// -> [{"field":"email","rule":"required","message":"Email address is required"}]
+//	}
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/oblq/i18n/v2"
+)
+
+const (
+	// DefaultKeyPrefix is the default validation messages keys prefix.
+	DefaultKeyPrefix = "validation"
+	// DefaultFieldKeyPrefix is the default field names keys prefix.
+	DefaultFieldKeyPrefix = "fields"
+	// DefaultRule is the rule used for rules not localized.
+	DefaultRule = "default"
+)
+
+// FieldError is a validation failure of a field,
+// github.com/go-playground/validator FieldError satisfies it.
+type FieldError interface {
+	// Field is the field name.
+	Field() string
+	// Tag is the failed rule, e.g.: `required` or `min`.
+	Tag() string
+	// Param is the rule param, e.g.: `8` for `min=8`.
+	Param() string
+}
+
+// Failure is a FieldError, for validation
+// performed without a validation library.
+type Failure struct {
+	FieldName string
+	Rule      string
+	RuleParam string
+}
+
+func (f Failure) Field() string { return f.FieldName }
+func (f Failure) Tag() string   { return f.Rule }
+func (f Failure) Param() string { return f.RuleParam }
+
+func (f Failure) Error() string {
+	return fmt.Sprintf("validation failed on field '%s' for rule '%s'", f.FieldName, f.Rule)
+}
+
+// Failures is a list of Failure, usable as an error.
+type Failures []Failure
+
+func (f Failures) Error() string {
+	messages := make([]string, len(f))
+	for i, failure := range f {
+		messages[i] = failure.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// LocalizedError is a localized validation failure,
+// suitable for API error payloads.
+type LocalizedError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// Translator translates validation failures.
+type Translator struct {
+	I18n *i18n.I18n
+
+	// KeyPrefix is the validation messages keys prefix.
+	// Default is DefaultKeyPrefix.
+	KeyPrefix string
+
+	// FieldKeyPrefix is the field names keys prefix.
+	// Default is DefaultFieldKeyPrefix.
+	FieldKeyPrefix string
+}
+
+// Translate localize the FieldError found in err
+// (a FieldError, or a slice of them such as Failures
+// or the validator ValidationErrors) in locale.
+// Nil is returned if err contains no FieldError.
+func (t *Translator) Translate(locale string, err error) []LocalizedError {
+	var localized []LocalizedError
+	for _, fe := range fieldErrors(err) {
+		localized = append(localized, LocalizedError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: t.Message(locale, fe),
+		})
+	}
+	return localized
+}
+
+// AutoTranslate localize err in the request locale,
+// see Translate and i18n.I18n.GetLocale.
+func (t *Translator) AutoTranslate(r *http.Request, err error) []LocalizedError {
+	return t.Translate(t.I18n.GetLocale(r), err)
+}
+
+// Message return the localized message of fe in locale,
+// `<KeyPrefix>.default` is used if the rule is not localized,
+// an English message if neither is.
+func (t *Translator) Message(locale string, fe FieldError) string {
+	key := t.keyPrefix() + "." + fe.Tag()
+
+	params := []interface{}{t.FieldName(locale, fe.Field())}
+	if len(fe.Param()) > 0 && t.args(locale, key) > 1 {
+		params = append(params, fe.Param())
+	}
+
+	if message := t.I18n.T(locale, key, params...); message != key {
+		return message
+	}
+
+	// the default message has the field name param only
+	key = t.keyPrefix() + "." + DefaultRule
+	if message := t.I18n.T(locale, key, params[0]); message != key {
+		return message
+	}
+	return fmt.Sprintf("%s is not valid (%s)", params[0], fe.Tag())
+}
+
+// FieldName return the localized field name,
+// or field if it is not localized.
+func (t *Translator) FieldName(locale string, field string) string {
+	key := t.fieldKeyPrefix() + "." + field
+	if name := t.I18n.T(locale, key); name != key {
+		return name
+	}
+	return field
+}
+
+// args return the number of params used by the localization
+// of key in locale, or in the default locale (pseudo-locales).
+func (t *Translator) args(locale string, key string) int {
+	store := t.I18n.Store()
+	for _, l := range []string{t.I18n.MatchAvailableLanguageTag(locale).String(), t.I18n.Tags[0].String()} {
+		if format, ok := store.Lookup(l, key); ok {
+			return formatArgs(format)
+		}
+	}
+	return 0
+}
+
+// formatArgs return the number of arguments used by format,
+// including explicit argument indexes, e.g.: `%[2]s`.
+func formatArgs(format string) int {
+	var args, arg int
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		// flags, width, precision and argument indexes
+		for i++; i < len(format); i++ {
+			c := format[i]
+			if c == '[' {
+				end := strings.IndexByte(format[i:], ']')
+				if end < 0 {
+					break
+				}
+				if index, err := strconv.Atoi(format[i+1 : i+end]); err == nil {
+					arg = index - 1
+				}
+				i += end
+				continue
+			}
+			if c == '*' {
+				arg++
+				continue
+			}
+			if !strings.ContainsRune("+-# 0123456789.", rune(c)) {
+				break
+			}
+		}
+
+		if i < len(format) && format[i] != '%' {
+			arg++
+		}
+		if arg > args {
+			args = arg
+		}
+	}
+	return args
+}
+
+func (t *Translator) keyPrefix() string {
+	if len(t.KeyPrefix) > 0 {
+		return t.KeyPrefix
+	}
+	return DefaultKeyPrefix
+}
+
+func (t *Translator) fieldKeyPrefix() string {
+	if len(t.FieldKeyPrefix) > 0 {
+		return t.FieldKeyPrefix
+	}
+	return DefaultFieldKeyPrefix
+}
+
+// fieldErrors return the FieldError in err, it can be a
+// FieldError or a slice of FieldError of any type.
+func fieldErrors(err error) []FieldError {
+	if err == nil {
+		return nil
+	}
+
+	var fe FieldError
+	if errors.As(err, &fe) {
+		return []FieldError{fe}
+	}
+
+	v := reflect.ValueOf(err)
+	if v.Kind() != reflect.Slice {
+		return nil
+	}
+
+	var fieldErrors []FieldError
+	for i := 0; i < v.Len(); i++ {
+		if fe, ok := v.Index(i).Interface().(FieldError); ok {
+			fieldErrors = append(fieldErrors, fe)
+		}
+	}
+	return fieldErrors
+}
@@ -0,0 +1,78 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oblq/i18n/v2"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// fieldError is a third party FieldError, as the validator one.
+type fieldError struct{ field, tag, param string }
+
+func (e fieldError) Field() string { return e.field }
+func (e fieldError) Tag() string   { return e.tag }
+func (e fieldError) Param() string { return e.param }
+func (e fieldError) Error() string { return e.field + " " + e.tag }
+
+type validationErrors []fieldError
+
+func (e validationErrors) Error() string { return "validation failed" }
+
+func TestTranslator(t *testing.T) {
+	localizer, err := i18n.NewWithConfig(&i18n.Config{
+		Locales: []string{language.English.String(), language.Italian.String()},
+		Path:    "../example/i18n",
+	})
+	assert.Equal(t, nil, err)
+	translator := &Translator{I18n: localizer}
+
+	failures := Failures{
+		{FieldName: "email", Rule: "required"},
+		{FieldName: "password", Rule: "min", RuleParam: "8"},
+		{FieldName: "age", Rule: "gte", RuleParam: "18"},
+	}
+	assert.Equal(t, []LocalizedError{
+		{Field: "email", Rule: "required", Message: "Indirizzo email è obbligatorio"},
+		{Field: "password", Rule: "min", Param: "8", Message: "Password deve contenere almeno 8 caratteri"},
+		{Field: "age", Rule: "gte", Param: "18", Message: "age non è valido"},
+	}, translator.Translate("it", failures))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Add("Accept-Language", "en-US")
+	assert.Equal(t, []LocalizedError{
+		{Field: "email", Rule: "email", Message: "Email address must be a valid email address"},
+	}, translator.AutoTranslate(r, fmt.Errorf("bad request: %w", fieldError{"email", "email", ""})))
+
+	assert.Equal(t, "Email address is required", translator.AutoTranslate(r, validationErrors{{"email", "required", ""}})[0].Message)
+	assert.Equal(t, 0, len(translator.Translate("en", errors.New("not a validation error"))))
+	assert.Equal(t, 0, len(translator.Translate("en", nil)))
+
+	custom := &Translator{I18n: localizer, KeyPrefix: "missing"}
+	assert.Equal(t, "Email address is not valid (required)", custom.Message("en", failures[0]))
+
+	// the rule param is passed only to messages with a second verb
+	assert.Equal(t, "Email address is required", translator.Message("en", Failure{FieldName: "email", Rule: "required", RuleParam: "x"}))
+	assert.Equal(t, "Email address is required", translator.Message("en-US", Failure{FieldName: "email", Rule: "required", RuleParam: "x"}))
+}
+
+func TestFormatArgs(t *testing.T) {
+	for format, args := range map[string]int{
+		"":                   0,
+		"100%% valid":        0,
+		"%s is required":     1,
+		"%s must be %s long": 2,
+		"%-10s %5.2f%%":      2,
+		"%[2]s for %[1]s":    2,
+		"%*d":                2,
+		"%[1]s":              1,
+		"trailing %":         0,
+	} {
+		assert.Equal(t, args, formatArgs(format), format)
+	}
+}